@@ -16,18 +16,28 @@ type Config interface {
 	Concourse() concourse.Client
 	Version() string
 	WorkerVersion() string
+	ServerInfo() *ServerInfo
 	UserInfo() *SkyUserInfo
 }
 
+// ServerInfo encapsulates all the information that is being reported by the ATC "api/v1/info" REST endpoint.
+// Feature flags are only reported by newer ATC versions.
+type ServerInfo struct {
+	Version       string          `json:"version"`
+	WorkerVersion string          `json:"worker_version"`
+	ExternalURL   string          `json:"external_url"`
+	ClusterName   string          `json:"cluster_name"`
+	FeatureFlags  map[string]bool `json:"feature_flags"`
+}
+
 type config struct {
-	url           string
-	insecure      bool
-	team          string
-	client        concourse.Client
-	userInfo      *SkyUserInfo
-	version       string
-	workerVersion string
-	log           *log.Logger
+	url        string
+	insecure   bool
+	team       string
+	client     concourse.Client
+	userInfo   *SkyUserInfo
+	serverInfo *ServerInfo
+	log        *log.Logger
 }
 
 func (c *config) Concourse() concourse.Client {
@@ -35,11 +45,15 @@ func (c *config) Concourse() concourse.Client {
 }
 
 func (c *config) Version() string {
-	return c.version
+	return c.serverInfo.Version
 }
 
 func (c *config) WorkerVersion() string {
-	return c.workerVersion
+	return c.serverInfo.WorkerVersion
+}
+
+func (c *config) ServerInfo() *ServerInfo {
+	return c.serverInfo
 }
 
 func (c *config) UserInfo() *SkyUserInfo {
@@ -54,10 +68,21 @@ func NewConfig(url *url.URL, httpClient *http.Client, insecure bool, team string
 
 	client := concourse.NewClient(url.String(), httpClient, false)
 
-	info, err := client.GetInfo()
+	// The info is fetched by hand, as the Concourse client does not decode all fields reported by the ATC.
+	infoURL := fmt.Sprintf("%s/%s", client.URL(), "api/v1/info")
+	infoResp, err := client.HTTPClient().Get(infoURL)
 	if err != nil {
 		return nil, fmt.Errorf("unable to contact Concourse CI: %v", err)
 	}
+	defer infoResp.Body.Close()
+	if infoResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to contact Concourse CI: %s returned status code %d", infoURL, infoResp.StatusCode)
+	}
+
+	serverInfo := &ServerInfo{}
+	if err := json.NewDecoder(infoResp.Body).Decode(serverInfo); err != nil {
+		return nil, fmt.Errorf("unable to gather server information: %v", err)
+	}
 
 	userInfoURL := fmt.Sprintf("%s/%s", client.URL(), "sky/userinfo")
 	resp, err := client.HTTPClient().Get(userInfoURL)
@@ -74,13 +99,12 @@ func NewConfig(url *url.URL, httpClient *http.Client, insecure bool, team string
 	}
 
 	return &config{
-		url:           url.String(),
-		insecure:      insecure,
-		team:          team,
-		client:        client,
-		userInfo:      userInfo,
-		version:       info.Version,
-		workerVersion: info.WorkerVersion,
+		url:        url.String(),
+		insecure:   insecure,
+		team:       team,
+		client:     client,
+		userInfo:   userInfo,
+		serverInfo: serverInfo,
 	}, nil
 
 }
@@ -2,6 +2,8 @@ package concourse
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -10,14 +12,43 @@ func dataCallerIdentityRead(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId(userInfo.UserID)
 
+	if err := d.Set("user_id", userInfo.UserID); err != nil {
+		return fmt.Errorf("unable to set user_id field: %v", err)
+	}
+
 	if err := d.Set("user_name", userInfo.UserName); err != nil {
 		return fmt.Errorf("unable to set user_name field: %v", err)
 	}
 
+	if err := d.Set("name", userInfo.Name); err != nil {
+		return fmt.Errorf("unable to set name field: %v", err)
+	}
+
+	if err := d.Set("email", userInfo.Email); err != nil {
+		return fmt.Errorf("unable to set email field: %v", err)
+	}
+
+	if err := d.Set("sub", userInfo.Sub); err != nil {
+		return fmt.Errorf("unable to set sub field: %v", err)
+	}
+
+	if err := d.Set("exp", userInfo.Exp); err != nil {
+		return fmt.Errorf("unable to set exp field: %v", err)
+	}
+
 	if err := d.Set("is_admin", userInfo.IsAdmin); err != nil {
 		return fmt.Errorf("unable to set is_admin field: %v", err)
 	}
 
+	// Terraform maps cannot hold lists, so the roles of each team are joined by commas.
+	teams := make(map[string]interface{}, len(userInfo.Teams))
+	for team, roles := range userInfo.Teams {
+		teams[team] = strings.Join(roles, ",")
+	}
+	if err := d.Set("teams", teams); err != nil {
+		return fmt.Errorf("unable to set teams field: %v", err)
+	}
+
 	return nil
 
 }
@@ -34,16 +65,47 @@ func dataCallerIdentity() *schema.Resource {
 		Read:   dataCallerIdentityRead,
 		Exists: dataCallerIdentityExists,
 		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "User ID of the current Concourse ATC API user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"user_name": {
 				Description: "User name of the current Concourse ATC API user",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"name": {
+				Description: "Display name of the current Concourse ATC API user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"email": {
+				Description: "Email address of the current Concourse ATC API user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sub": {
+				Description: "Subject of the current Concourse ATC API user's token",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"exp": {
+				Description: "Expiry of the current Concourse ATC API user's token (unix timestamp)",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 			"is_admin": {
 				Description: "Flag that indicates if the current Concourse ATC API user has admin permissions",
 				Type:        schema.TypeBool,
 				Computed:    true,
 			},
+			"teams": {
+				Description: "Map of team names to the comma separated roles the current Concourse ATC API user holds",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -0,0 +1,48 @@
+package concourse
+
+import (
+	"testing"
+)
+
+func TestDataCallerIdentity(t *testing.T) {
+	fake := newFakeATC()
+	fake.UserInfo = SkyUserInfo{
+		UserID:   "github:alice",
+		UserName: "alice",
+		Name:     "Alice",
+		Email:    "alice@example.com",
+		Sub:      "Cgdhbm9ueW1vdXMSBmdpdGh1Yg",
+		Exp:      1600000000,
+		IsAdmin:  false,
+		Teams: map[string][]string{
+			"main":   {"member"},
+			"team-a": {"owner", "viewer"},
+		},
+	}
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	state, err := readData(t, dataCallerIdentity(), map[string]interface{}{}, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"id":           "github:alice",
+		"user_id":      "github:alice",
+		"user_name":    "alice",
+		"name":         "Alice",
+		"email":        "alice@example.com",
+		"sub":          "Cgdhbm9ueW1vdXMSBmdpdGh1Yg",
+		"exp":          "1600000000",
+		"is_admin":     "false",
+		"teams.%":      "2",
+		"teams.main":   "member",
+		"teams.team-a": "owner,viewer",
+	}
+	for k, v := range expected {
+		if state.Attributes[k] != v {
+			t.Fatalf("expected %s to be %q, got %q", k, v, state.Attributes[k])
+		}
+	}
+}
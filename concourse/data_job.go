@@ -0,0 +1,202 @@
+package concourse
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// succeededBuildSearchPages limits the number of pages of 100 builds that are searched for a succeeded build, so that
+// jobs with a long history of failed builds do not slow down every refresh.
+var succeededBuildSearchPages = 10
+
+// latestSucceededBuild pages through the builds of a job (newest first) until a succeeded build has been found. No
+// build is returned if none of the searched builds has succeeded, a warning is logged if older builds have not been
+// searched.
+func latestSucceededBuild(team concourse.Team, pipeline, job string) (*atc.Build, error) {
+	page := concourse.Page{Limit: 100}
+	for i := 0; i < succeededBuildSearchPages; i++ {
+		builds, pagination, found, err := team.JobBuilds(pipeline, job, page)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list builds of job \"%s\" in pipeline \"%s\": %v", job, pipeline, err)
+		}
+		if !found {
+			return nil, nil
+		}
+		for _, build := range builds {
+			if atc.BuildStatus(build.Status) == atc.StatusSucceeded {
+				return &build, nil
+			}
+		}
+		if pagination.Next == nil {
+			return nil, nil
+		}
+		page = *pagination.Next
+	}
+	log.Printf("[WARN] no succeeded build found in the latest %d builds of job \"%s\" in pipeline \"%s\", older builds are not searched", succeededBuildSearchPages*100, job, pipeline)
+	return nil, nil
+}
+
+func dataJobRead(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	name := d.Get("name").(string)
+
+	team := m.(Config).Concourse().Team(teamName)
+
+	job, found, err := team.Job(pipeline, name)
+	if err != nil {
+		return fmt.Errorf("unable to fetch job \"%s\" of pipeline \"%s\" in team \"%s\": %v", name, pipeline, teamName, err)
+	}
+	if !found {
+		return fmt.Errorf("job \"%s\" not found in pipeline \"%s\" of team \"%s\"", name, pipeline, teamName)
+	}
+
+	succeeded, err := latestSucceededBuild(team, pipeline, name)
+	if err != nil {
+		return err
+	}
+
+	inputs := make([]interface{}, len(job.Inputs))
+	for i, input := range job.Inputs {
+		passed := make([]interface{}, len(input.Passed))
+		for j, p := range input.Passed {
+			passed[j] = p
+		}
+		inputs[i] = map[string]interface{}{
+			"name":     input.Name,
+			"resource": input.Resource,
+			"trigger":  input.Trigger,
+			"passed":   passed,
+		}
+	}
+
+	outputs := make([]interface{}, len(job.Outputs))
+	for i, output := range job.Outputs {
+		outputs[i] = map[string]interface{}{
+			"name":     output.Name,
+			"resource": output.Resource,
+		}
+	}
+
+	groups := make([]interface{}, len(job.Groups))
+	for i, group := range job.Groups {
+		groups[i] = group
+	}
+
+	d.SetId(jobID(teamName, pipeline, job.Name))
+	d.Set("job_id", job.ID)
+	d.Set("paused", job.Paused)
+
+	if err := d.Set("inputs", inputs); err != nil {
+		return fmt.Errorf("unable to set inputs field: %v", err)
+	}
+	if err := d.Set("outputs", outputs); err != nil {
+		return fmt.Errorf("unable to set outputs field: %v", err)
+	}
+	if err := d.Set("groups", groups); err != nil {
+		return fmt.Errorf("unable to set groups field: %v", err)
+	}
+
+	setJobBuild(d, "finished_build", job.FinishedBuild)
+	setJobBuild(d, "next_build", job.NextBuild)
+	setJobBuild(d, "succeeded_build", succeeded)
+
+	return nil
+}
+
+func dataJob() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"team": {
+			Description: "Team name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"pipeline": {
+			Description: "Pipeline name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"name": {
+			Description: "Job name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"job_id": {
+			Description: "Numeric job ID",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"paused": {
+			Description: "Paused",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"inputs": {
+			Description: "Inputs of the job",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Input name",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"resource": {
+						Description: "Resource name",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"trigger": {
+						Description: "Whether new versions trigger the job",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"passed": {
+						Description: "Jobs the input must have passed",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"outputs": {
+			Description: "Outputs of the job",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Output name",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"resource": {
+						Description: "Resource name",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"groups": {
+			Description: "Groups the job belongs to",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	s = jobBuildSchema(s, "finished_build", "latest finished build")
+	s = jobBuildSchema(s, "next_build", "next (pending or running) build")
+	s = jobBuildSchema(s, "succeeded_build", "latest succeeded build")
+
+	return &schema.Resource{
+		Read:   dataJobRead,
+		Schema: s,
+	}
+}
@@ -0,0 +1,181 @@
+package concourse
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataJobBuildsRead(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	job := d.Get("job").(string)
+
+	if job != "" && pipeline == "" {
+		return fmt.Errorf("pipeline must be set if job is set")
+	}
+
+	page := concourse.Page{
+		Since: d.Get("since").(int),
+		Until: d.Get("until").(int),
+		Limit: d.Get("limit").(int),
+	}
+
+	team := m.(Config).Concourse().Team(teamName)
+
+	var builds []atc.Build
+	var pagination concourse.Pagination
+	var found = true
+	var err error
+	var id string
+	switch {
+	case job != "":
+		builds, pagination, found, err = team.JobBuilds(pipeline, job, page)
+		id = jobID(teamName, pipeline, job)
+	case pipeline != "":
+		builds, pagination, found, err = team.PipelineBuilds(pipeline, page)
+		id = pipelineID(teamName, pipeline)
+	default:
+		builds, pagination, err = team.Builds(page)
+		id = teamName
+	}
+	if err != nil {
+		return fmt.Errorf("unable to list builds of %s: %v", id, err)
+	}
+	if !found {
+		return fmt.Errorf("no builds found for %s, the pipeline or job does not exist", id)
+	}
+
+	result := make([]interface{}, len(builds))
+	for i, build := range builds {
+		result[i] = map[string]interface{}{
+			"id":         build.ID,
+			"name":       build.Name,
+			"status":     build.Status,
+			"team":       build.TeamName,
+			"pipeline":   build.PipelineName,
+			"job":        build.JobName,
+			"start_time": int(build.StartTime),
+			"end_time":   int(build.EndTime),
+		}
+	}
+
+	d.SetId(id)
+
+	if err := d.Set("builds", result); err != nil {
+		return fmt.Errorf("unable to set builds field: %v", err)
+	}
+
+	// The ATC links the next (older) page using "since" and the previous (newer) page using "until".
+	nextSince, previousUntil := 0, 0
+	if pagination.Next != nil {
+		nextSince = pagination.Next.Since
+	}
+	if pagination.Previous != nil {
+		previousUntil = pagination.Previous.Until
+	}
+	d.Set("next_since", nextSince)
+	d.Set("previous_until", previousUntil)
+
+	return nil
+}
+
+func dataJobBuilds() *schema.Resource {
+	return &schema.Resource{
+		Read: dataJobBuildsRead,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"pipeline": {
+				Description: "Pipeline name (builds of the whole team are listed if omitted)",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"job": {
+				Description: "Job name (builds of the whole pipeline are listed if omitted)",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"since": {
+				Description: "Only list builds with an ID less than the given one (older builds)",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"until": {
+				Description: "Only list builds with an ID greater than the given one (newer builds)",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"limit": {
+				Description: "Maximum number of builds to list",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     50,
+			},
+			"builds": {
+				Description: "Builds, most recent first",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Build ID",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Build name (build number)",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Build status",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"team": {
+							Description: "Team name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"pipeline": {
+							Description: "Pipeline name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"job": {
+							Description: "Job name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"start_time": {
+							Description: "Start time of the build (unix timestamp)",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"end_time": {
+							Description: "End time of the build (unix timestamp)",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"next_since": {
+				Description: "Value of \"since\" to fetch the next (older) page of builds, 0 if there is none",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"previous_until": {
+				Description: "Value of \"until\" to fetch the previous (newer) page of builds, 0 if there is none",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package concourse
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestDataJobBuilds(t *testing.T) {
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	fake.AddTeam("other", nil)
+	fake.AddPipeline("main", "p1", atc.Config{})
+	fake.AddPipeline("main", "p2", atc.Config{})
+	fake.AddJob("main", "p1", atc.Job{Name: "a"})
+	fake.AddJob("main", "p1", atc.Job{Name: "b"})
+	fake.AddJob("main", "p2", atc.Job{Name: "c"})
+
+	// Builds of all jobs are interleaved, so that listing the builds of a job has to filter them.
+	var a, b, c []atc.Build
+	for i := 0; i < 5; i++ {
+		a = append(a, fake.AddBuild("main", "p1", "a", atc.StatusSucceeded))
+		b = append(b, fake.AddBuild("main", "p1", "b", atc.StatusFailed))
+		c = append(c, fake.AddBuild("main", "p2", "c", atc.StatusSucceeded))
+	}
+	fake.AddBuild("other", "p3", "d", atc.StatusSucceeded)
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	cases := []struct {
+		name             string
+		config           map[string]interface{}
+		expected         []atc.Build
+		expectedNext     int
+		expectedPrevious int
+	}{
+		{
+			name:         "first page of a job",
+			config:       map[string]interface{}{"pipeline": "p1", "job": "a", "limit": 2},
+			expected:     []atc.Build{a[4], a[3]},
+			expectedNext: a[3].ID,
+		},
+		{
+			name:             "next page of a job",
+			config:           map[string]interface{}{"pipeline": "p1", "job": "a", "limit": 2, "since": a[3].ID},
+			expected:         []atc.Build{a[2], a[1]},
+			expectedNext:     a[1].ID,
+			expectedPrevious: a[2].ID,
+		},
+		{
+			name:             "last page of a job",
+			config:           map[string]interface{}{"pipeline": "p1", "job": "a", "limit": 2, "since": a[1].ID},
+			expected:         []atc.Build{a[0]},
+			expectedPrevious: a[0].ID,
+		},
+		{
+			name:         "previous page of a job",
+			config:       map[string]interface{}{"pipeline": "p1", "job": "a", "limit": 2, "until": a[2].ID},
+			expected:     []atc.Build{a[4], a[3]},
+			expectedNext: a[3].ID,
+		},
+		{
+			name:         "pipeline",
+			config:       map[string]interface{}{"pipeline": "p1", "limit": 3},
+			expected:     []atc.Build{b[4], a[4], b[3]},
+			expectedNext: b[3].ID,
+		},
+		{
+			name:     "team",
+			config:   map[string]interface{}{},
+			expected: []atc.Build{c[4], b[4], a[4], c[3], b[3], a[3], c[2], b[2], a[2], c[1], b[1], a[1], c[0], b[0], a[0]},
+		},
+	}
+
+	for _, c := range cases {
+		raw := map[string]interface{}{"team": "main"}
+		for k, v := range c.config {
+			raw[k] = v
+		}
+
+		state, err := readData(t, dataJobBuilds(), raw, cfg)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+
+		if count := state.Attributes["builds.#"]; count != strconv.Itoa(len(c.expected)) {
+			t.Fatalf("%s: expected %d builds, got %s", c.name, len(c.expected), count)
+		}
+		for i, build := range c.expected {
+			prefix := "builds." + strconv.Itoa(i) + "."
+			if state.Attributes[prefix+"id"] != strconv.Itoa(build.ID) || state.Attributes[prefix+"job"] != build.JobName ||
+				state.Attributes[prefix+"status"] != build.Status {
+				t.Fatalf("%s: expected build %d of job %s at index %d, got %s of job %s", c.name, build.ID, build.JobName, i,
+					state.Attributes[prefix+"id"], state.Attributes[prefix+"job"])
+			}
+		}
+		if state.Attributes["next_since"] != strconv.Itoa(c.expectedNext) {
+			t.Fatalf("%s: expected next page since %d, got %s", c.name, c.expectedNext, state.Attributes["next_since"])
+		}
+		if state.Attributes["previous_until"] != strconv.Itoa(c.expectedPrevious) {
+			t.Fatalf("%s: expected previous page until %d, got %s", c.name, c.expectedPrevious, state.Attributes["previous_until"])
+		}
+	}
+
+	errorCases := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{name: "job without pipeline", config: map[string]interface{}{"team": "main", "job": "a"}},
+		{name: "missing job", config: map[string]interface{}{"team": "main", "pipeline": "p1", "job": "missing"}},
+		{name: "missing pipeline", config: map[string]interface{}{"team": "main", "pipeline": "missing"}},
+	}
+	for _, c := range errorCases {
+		if _, err := readData(t, dataJobBuilds(), c.config, cfg); err == nil {
+			t.Fatalf("%s: expected error", c.name)
+		}
+	}
+}
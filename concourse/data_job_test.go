@@ -0,0 +1,67 @@
+package concourse
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestDataJobSucceededBuild(t *testing.T) {
+	defer func(pages int) { succeededBuildSearchPages = pages }(succeededBuildSearchPages)
+	succeededBuildSearchPages = 2
+
+	cases := []struct {
+		name           string
+		succeeded      bool
+		failed         int
+		expectedStatus string
+		expectWarn     bool
+	}{
+		{name: "first page", succeeded: true, failed: 50, expectedStatus: "succeeded"},
+		{name: "last searched page", succeeded: true, failed: 150, expectedStatus: "succeeded"},
+		{name: "beyond the searched pages", succeeded: true, failed: 200, expectWarn: true},
+		{name: "never succeeded", failed: 150},
+	}
+
+	for _, c := range cases {
+		fake := newFakeATC()
+		fake.AddTeam("main", nil)
+		fake.AddPipeline("main", "p1", atc.Config{})
+		fake.AddJob("main", "p1", atc.Job{Name: "build"})
+		var succeeded atc.Build
+		if c.succeeded {
+			succeeded = fake.AddBuild("main", "p1", "build", atc.StatusSucceeded)
+		}
+		for i := 0; i < c.failed; i++ {
+			fake.AddBuild("main", "p1", "build", atc.StatusFailed)
+		}
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		var logs bytes.Buffer
+		log.SetOutput(&logs)
+		state, err := readData(t, dataJob(), map[string]interface{}{
+			"team":     "main",
+			"pipeline": "p1",
+			"name":     "build",
+		}, cfg)
+		log.SetOutput(os.Stderr)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+
+		if state.Attributes["succeeded_build_status"] != c.expectedStatus {
+			t.Fatalf("%s: expected succeeded build status %q, got %q", c.name, c.expectedStatus, state.Attributes["succeeded_build_status"])
+		}
+		if c.expectedStatus != "" && state.Attributes["succeeded_build_name"] != succeeded.Name {
+			t.Fatalf("%s: expected succeeded build %s, got %s", c.name, succeeded.Name, state.Attributes["succeeded_build_name"])
+		}
+		if warned := strings.Contains(logs.String(), "[WARN] no succeeded build found in the latest 200 builds"); warned != c.expectWarn {
+			t.Fatalf("%s: expected warning %v, got log %s", c.name, c.expectWarn, logs.String())
+		}
+	}
+}
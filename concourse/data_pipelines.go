@@ -0,0 +1,144 @@
+package concourse
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataPipelinesRead(d *schema.ResourceData, m interface{}) error {
+	concourse := m.(Config).Concourse()
+
+	team := d.Get("team").(string)
+
+	var pipelines []atc.Pipeline
+	var err error
+	if team != "" {
+		pipelines, err = concourse.Team(team).ListPipelines()
+		if err != nil {
+			return fmt.Errorf("unable to list pipelines of team \"%s\": %v", team, err)
+		}
+	} else {
+		pipelines, err = concourse.ListPipelines()
+		if err != nil {
+			return fmt.Errorf("unable to list pipelines: %v", err)
+		}
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		if nameRegex, err = regexp.Compile(v.(string)); err != nil {
+			return fmt.Errorf("invalid name_regex \"%s\": %v", v.(string), err)
+		}
+	}
+	paused, filterPaused := d.GetOkExists("paused")
+	public, filterPublic := d.GetOkExists("public")
+
+	names := make([]interface{}, 0, len(pipelines))
+	result := make([]interface{}, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		if nameRegex != nil && !nameRegex.MatchString(pipeline.Name) {
+			continue
+		}
+		if filterPaused && pipeline.Paused != paused.(bool) {
+			continue
+		}
+		if filterPublic && pipeline.Public != public.(bool) {
+			continue
+		}
+		names = append(names, pipeline.Name)
+		result = append(result, map[string]interface{}{
+			"id":     pipeline.ID,
+			"name":   pipeline.Name,
+			"team":   pipeline.TeamName,
+			"paused": pipeline.Paused,
+			"public": pipeline.Public,
+		})
+	}
+
+	if team != "" {
+		d.SetId(team)
+	} else {
+		d.SetId(concourse.URL())
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("unable to set names field: %v", err)
+	}
+
+	if err := d.Set("pipelines", result); err != nil {
+		return fmt.Errorf("unable to set pipelines field: %v", err)
+	}
+
+	return nil
+}
+
+func dataPipelines() *schema.Resource {
+	return &schema.Resource{
+		Read: dataPipelinesRead,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name (pipelines of all teams are listed if omitted)",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"name_regex": {
+				Description: "Regular expression that pipeline names must match",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"paused": {
+				Description: "Only list pipelines with the given paused state",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"public": {
+				Description: "Only list pipelines with the given public state",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"names": {
+				Description: "Names of all matching pipelines",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"pipelines": {
+				Description: "All matching pipelines",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Numeric pipeline ID",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Pipeline name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"team": {
+							Description: "Team name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"paused": {
+							Description: "Paused",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"public": {
+							Description: "Public",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package concourse
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestDataPipelines(t *testing.T) {
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	fake.AddTeam("team-a", nil)
+	fake.AddPipeline("main", "app-build", atc.Config{})
+	fake.AddPipeline("main", "app-deploy", atc.Config{})
+	fake.AddPipeline("main", "infra", atc.Config{})
+	fake.AddPipeline("team-a", "app-test", atc.Config{})
+	fake.Pipelines["main"][0].Paused = true
+	fake.Pipelines["main"][1].Public = true
+	fake.Pipelines["team-a"][0].Public = true
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	cases := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedNames []string
+	}{
+		{name: "one team", config: map[string]interface{}{"team": "main"}, expectedNames: []string{"app-build", "app-deploy", "infra"}},
+		{name: "all teams", config: map[string]interface{}{}, expectedNames: []string{"app-build", "app-deploy", "infra", "app-test"}},
+		{name: "name regex", config: map[string]interface{}{"name_regex": "^app-"}, expectedNames: []string{"app-build", "app-deploy", "app-test"}},
+		{name: "paused", config: map[string]interface{}{"paused": true}, expectedNames: []string{"app-build"}},
+		{name: "not paused", config: map[string]interface{}{"paused": false}, expectedNames: []string{"app-deploy", "infra", "app-test"}},
+		{name: "public", config: map[string]interface{}{"team": "main", "public": true}, expectedNames: []string{"app-deploy"}},
+		{name: "not public", config: map[string]interface{}{"public": false}, expectedNames: []string{"app-build", "infra"}},
+		{name: "no match", config: map[string]interface{}{"name_regex": "^web-"}, expectedNames: []string{}},
+	}
+
+	for _, c := range cases {
+		state, err := readData(t, dataPipelines(), c.config, cfg)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+
+		names := []string{}
+		for i := 0; i < len(c.expectedNames)+1; i++ {
+			if name, ok := state.Attributes["names."+strconv.Itoa(i)]; ok {
+				names = append(names, name)
+			}
+		}
+		if !reflect.DeepEqual(names, c.expectedNames) {
+			t.Fatalf("%s: expected pipelines %v, got %v", c.name, c.expectedNames, names)
+		}
+		if count := state.Attributes["pipelines.#"]; count != strconv.Itoa(len(c.expectedNames)) {
+			t.Fatalf("%s: expected %d pipelines, got %s", c.name, len(c.expectedNames), count)
+		}
+	}
+
+	state, err := readData(t, dataPipelines(), map[string]interface{}{"team": "team-a"}, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"id":                 "team-a",
+		"pipelines.0.name":   "app-test",
+		"pipelines.0.team":   "team-a",
+		"pipelines.0.paused": "false",
+		"pipelines.0.public": "true",
+	}
+	for k, v := range expected {
+		if state.Attributes[k] != v {
+			t.Fatalf("expected %s to be %q, got %q", k, v, state.Attributes[k])
+		}
+	}
+
+	if _, err := readData(t, dataPipelines(), map[string]interface{}{"name_regex": "app-("}, cfg); err == nil {
+		t.Fatalf("expected invalid name_regex to fail")
+	}
+}
@@ -0,0 +1,175 @@
+package concourse
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataResourceVersionsRead(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+	filter := expandVersion(d.Get("version").(map[string]interface{}))
+	enabledOnly := d.Get("enabled_only").(bool)
+	limit := d.Get("limit").(int)
+
+	team := m.(Config).Concourse().Team(teamName)
+
+	versions := make([]interface{}, 0)
+	page := concourse.Page{Limit: 100}
+pages:
+	for {
+		results, pagination, found, err := team.ResourceVersions(pipeline, resource, page, filter)
+		if err != nil {
+			return fmt.Errorf("unable to list versions of resource \"%s\" in pipeline \"%s\": %v", resource, pipeline, err)
+		}
+		if !found {
+			return fmt.Errorf("resource \"%s\" not found in pipeline \"%s\" of team \"%s\"", resource, pipeline, teamName)
+		}
+		for _, version := range results {
+			if enabledOnly && !version.Enabled {
+				continue
+			}
+			if !versionMatches(version.Version, filter) {
+				continue
+			}
+			metadata := make([]interface{}, len(version.Metadata))
+			for i, field := range version.Metadata {
+				metadata[i] = map[string]interface{}{
+					"name":  field.Name,
+					"value": field.Value,
+				}
+			}
+			versions = append(versions, map[string]interface{}{
+				"id":       version.ID,
+				"version":  flattenVersion(version.Version),
+				"metadata": metadata,
+				"enabled":  version.Enabled,
+			})
+			if limit > 0 && len(versions) >= limit {
+				break pages
+			}
+		}
+		if pagination.Next == nil {
+			break
+		}
+		page = *pagination.Next
+	}
+
+	d.SetId(pipelineResourceID(teamName, pipeline, resource))
+
+	if err := d.Set("versions", versions); err != nil {
+		return fmt.Errorf("unable to set versions field: %v", err)
+	}
+
+	latestID := 0
+	latest := map[string]interface{}{}
+	if len(versions) > 0 {
+		latestID = versions[0].(map[string]interface{})["id"].(int)
+		latest = versions[0].(map[string]interface{})["version"].(map[string]interface{})
+	}
+	d.Set("latest_id", latestID)
+	if err := d.Set("latest", latest); err != nil {
+		return fmt.Errorf("unable to set latest field: %v", err)
+	}
+
+	return nil
+}
+
+func dataResourceVersions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataResourceVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"pipeline": {
+				Description: "Pipeline name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"resource": {
+				Description: "Resource name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"version": {
+				Description: "Only list versions that match all of the given version fields",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"enabled_only": {
+				Description: "Only list enabled versions",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"limit": {
+				Description: "Maximum number of versions to list (0 lists all versions)",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+			},
+			"versions": {
+				Description: "Matching versions, most recent first",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Resource version ID",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"version": {
+							Description: "Version fields",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"metadata": {
+							Description: "Version metadata",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Description: "Metadata field name",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"value": {
+										Description: "Metadata field value",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"enabled": {
+							Description: "Whether the version is enabled",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"latest": {
+				Description: "Version fields of the most recent matching version",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"latest_id": {
+				Description: "Resource version ID of the most recent matching version",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package concourse
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestDataResourceVersions(t *testing.T) {
+	var versions []atc.Version
+	for i := 0; i < 150; i++ {
+		branch := "master"
+		if i%2 == 1 {
+			branch = "develop"
+		}
+		versions = append(versions, atc.Version{"ref": strconv.Itoa(i), "branch": branch})
+	}
+	fake, added := newFakeResourceATC(versions...)
+	fake.ResourceVersions["main/p1/repo"][148].Enabled = false
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	cases := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedCount int
+		expectedIDs   []int
+	}{
+		{
+			name:          "default limit",
+			config:        map[string]interface{}{},
+			expectedCount: 100,
+			expectedIDs:   []int{added[149].ID, added[148].ID},
+		},
+		{
+			name:          "all versions across pages",
+			config:        map[string]interface{}{"limit": 0},
+			expectedCount: 150,
+			expectedIDs:   []int{added[149].ID, added[148].ID},
+		},
+		{
+			name:          "enabled only",
+			config:        map[string]interface{}{"enabled_only": true, "limit": 2},
+			expectedCount: 2,
+			expectedIDs:   []int{added[149].ID, added[147].ID},
+		},
+		{
+			name:          "version filter",
+			config:        map[string]interface{}{"version": map[string]interface{}{"branch": "master"}, "limit": 0},
+			expectedCount: 75,
+			expectedIDs:   []int{added[148].ID, added[146].ID},
+		},
+		{
+			name:          "no match",
+			config:        map[string]interface{}{"version": map[string]interface{}{"branch": "feature"}},
+			expectedCount: 0,
+		},
+	}
+
+	for _, c := range cases {
+		raw := map[string]interface{}{"team": "main", "pipeline": "p1", "resource": "repo"}
+		for k, v := range c.config {
+			raw[k] = v
+		}
+
+		state, err := readData(t, dataResourceVersions(), raw, cfg)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		if count := state.Attributes["versions.#"]; count != strconv.Itoa(c.expectedCount) {
+			t.Fatalf("%s: expected %d versions, got %s", c.name, c.expectedCount, count)
+		}
+		for i, id := range c.expectedIDs {
+			if actual := state.Attributes["versions."+strconv.Itoa(i)+".id"]; actual != strconv.Itoa(id) {
+				t.Fatalf("%s: expected version %d at index %d, got %s", c.name, id, i, actual)
+			}
+		}
+
+		latestID := "0"
+		if len(c.expectedIDs) > 0 {
+			latestID = strconv.Itoa(c.expectedIDs[0])
+		}
+		if state.Attributes["latest_id"] != latestID {
+			t.Fatalf("%s: expected latest version %s, got %s", c.name, latestID, state.Attributes["latest_id"])
+		}
+		if c.expectedCount == 0 && state.Attributes["latest.%"] != "0" {
+			t.Fatalf("%s: expected no latest version, got %v", c.name, state.Attributes)
+		}
+	}
+
+	// Resources that do not exist fail the read.
+	if _, err := readData(t, dataResourceVersions(), map[string]interface{}{
+		"team":     "main",
+		"pipeline": "p1",
+		"resource": "missing",
+	}, cfg); err == nil {
+		t.Fatalf("expected missing resource to fail")
+	}
+}
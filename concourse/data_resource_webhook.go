@@ -0,0 +1,123 @@
+package concourse
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var varPattern = regexp.MustCompile(`\(\(([^()]+)\)\)`)
+
+// resolveStaticVars replaces all "((var))" placeholders in s with the values of the given vars. An error is
+// returned if a placeholder cannot be resolved.
+func resolveStaticVars(s string, vars map[string]interface{}) (string, error) {
+	var missing []string
+	resolved := varPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := strings.TrimSpace(varPattern.FindStringSubmatch(placeholder)[1])
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return placeholder
+		}
+		return value.(string)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined vars: %s", strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+func dataResourceWebhookRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(Config).Concourse()
+
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+	vars := d.Get("vars").(map[string]interface{})
+
+	config, _, found, err := client.Team(team).PipelineConfig(pipeline)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration of pipeline \"%s\" in team \"%s\": %v", pipeline, team, err)
+	}
+	if !found {
+		return fmt.Errorf("pipeline \"%s\" not found in team \"%s\"", pipeline, team)
+	}
+
+	resourceConfig, found := config.Resources.Lookup(resource)
+	if !found {
+		return fmt.Errorf("resource \"%s\" not found in pipeline \"%s\" of team \"%s\"", resource, pipeline, team)
+	}
+	if resourceConfig.WebhookToken == "" {
+		return fmt.Errorf("resource \"%s\" of pipeline \"%s\" does not have a webhook_token", resource, pipeline)
+	}
+
+	token, err := resolveStaticVars(resourceConfig.WebhookToken, vars)
+	if err != nil {
+		return fmt.Errorf("unable to resolve webhook_token of resource \"%s\": %v", resource, err)
+	}
+
+	webhookURL := fmt.Sprintf("%s/api/v1/teams/%s/pipelines/%s/resources/%s/check/webhook?webhook_token=%s",
+		strings.TrimRight(client.URL(), "/"),
+		url.PathEscape(team),
+		url.PathEscape(pipeline),
+		url.PathEscape(resource),
+		url.QueryEscape(token),
+	)
+
+	d.SetId(pipelineResourceID(team, pipeline, resource))
+
+	if err := d.Set("url", webhookURL); err != nil {
+		return fmt.Errorf("unable to set url field: %v", err)
+	}
+
+	if err := d.Set("webhook_token", token); err != nil {
+		return fmt.Errorf("unable to set webhook_token field: %v", err)
+	}
+
+	return nil
+}
+
+func dataResourceWebhook() *schema.Resource {
+	return &schema.Resource{
+		Read: dataResourceWebhookRead,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"pipeline": {
+				Description: "Pipeline name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"resource": {
+				Description: "Resource name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"vars": {
+				Description: "Static vars used to resolve ((var)) placeholders in the webhook token",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"url": {
+				Description: "Webhook URL",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"webhook_token": {
+				Description: "Webhook token",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package concourse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestResolveStaticVars(t *testing.T) {
+	vars := map[string]interface{}{
+		"token":  "s3cr3t",
+		"suffix": "-prod",
+	}
+
+	resolved, err := resolveStaticVars("((token))(( suffix ))", vars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved != "s3cr3t-prod" {
+		t.Fatalf("expected \"s3cr3t-prod\", got \"%s\"", resolved)
+	}
+
+	resolved, err = resolveStaticVars("plain", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved != "plain" {
+		t.Fatalf("expected \"plain\", got \"%s\"", resolved)
+	}
+
+	if _, err := resolveStaticVars("((missing))", vars); err == nil {
+		t.Fatalf("expected an error for an undefined var")
+	}
+}
+
+func TestDataResourceWebhook(t *testing.T) {
+	fake := newFakeATC()
+	fake.AddTeam("dev team", nil)
+	fake.AddPipeline("dev team", "app #1", atc.Config{
+		Resources: atc.ResourceConfigs{
+			{Name: "repo?x", Type: "git", WebhookToken: "((token))&more"},
+			{Name: "plain", Type: "git"},
+		},
+	})
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	raw := map[string]interface{}{
+		"team":     "dev team",
+		"pipeline": "app #1",
+		"resource": "repo?x",
+		"vars":     map[string]interface{}{"token": "s3cr3t"},
+	}
+
+	state, err := readData(t, dataResourceWebhook(), raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := cfg.Concourse().URL() + "/api/v1/teams/dev%20team/pipelines/app%20%231/resources/repo%3Fx/check/webhook?webhook_token=s3cr3t%26more"
+	if state.Attributes["url"] != expected {
+		t.Fatalf("expected URL %s, got %s", expected, state.Attributes["url"])
+	}
+	if state.Attributes["webhook_token"] != "s3cr3t&more" {
+		t.Fatalf("expected resolved webhook token, got %s", state.Attributes["webhook_token"])
+	}
+
+	errorCases := []struct {
+		name          string
+		pipeline      string
+		resource      string
+		expectedError string
+	}{
+		{name: "missing pipeline", pipeline: "missing", resource: "repo?x", expectedError: "pipeline \"missing\" not found"},
+		{name: "missing resource", pipeline: "app #1", resource: "missing", expectedError: "resource \"missing\" not found"},
+		{name: "no webhook token", pipeline: "app #1", resource: "plain", expectedError: "does not have a webhook_token"},
+	}
+	for _, c := range errorCases {
+		raw["pipeline"], raw["resource"] = c.pipeline, c.resource
+		if _, err := readData(t, dataResourceWebhook(), raw, cfg); err == nil || !strings.Contains(err.Error(), c.expectedError) {
+			t.Fatalf("%s: expected error containing %q, got %v", c.name, c.expectedError, err)
+		}
+	}
+}
@@ -1,12 +1,64 @@
 package concourse
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
+
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
+// credentialManagers fetches the configuration and health of the credential managers the ATC has been
+// configured with. The "api/v1/info/creds" endpoint is only accessible by admins.
+func credentialManagers(cfg Config) ([]interface{}, error) {
+	client := cfg.Concourse()
+
+	credsURL := fmt.Sprintf("%s/%s", client.URL(), "api/v1/info/creds")
+	resp, err := client.HTTPClient().Get(credsURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to communicate with the Concourse CI API server: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status code %d", credsURL, resp.StatusCode)
+	}
+
+	managers := map[string]map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&managers); err != nil {
+		return nil, fmt.Errorf("unable to decode credential manager information: %v", err)
+	}
+
+	names := make([]string, 0, len(managers))
+	for name := range managers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]interface{}, len(names))
+	for i, name := range names {
+		info, err := json.Marshal(managers[name])
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode information of credential manager \"%s\": %v", name, err)
+		}
+
+		healthError := ""
+		if health, ok := managers[name]["health"].(map[string]interface{}); ok {
+			healthError, _ = health["error"].(string)
+		}
+
+		result[i] = map[string]interface{}{
+			"name":         name,
+			"info":         string(info),
+			"health_error": healthError,
+		}
+	}
+	return result, nil
+}
+
 func dataServerInfoRead(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(Config)
+	info := cfg.ServerInfo()
 
 	d.SetId(cfg.Concourse().URL())
 
@@ -18,6 +70,34 @@ func dataServerInfoRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("unable to set worker_version field: %v", err)
 	}
 
+	if err := d.Set("cluster_name", info.ClusterName); err != nil {
+		return fmt.Errorf("unable to set cluster_name field: %v", err)
+	}
+
+	if err := d.Set("external_url", info.ExternalURL); err != nil {
+		return fmt.Errorf("unable to set external_url field: %v", err)
+	}
+
+	featureFlags := make(map[string]interface{}, len(info.FeatureFlags))
+	for flag, enabled := range info.FeatureFlags {
+		featureFlags[flag] = enabled
+	}
+	if err := d.Set("feature_flags", featureFlags); err != nil {
+		return fmt.Errorf("unable to set feature_flags field: %v", err)
+	}
+
+	// Credential managers can only be inspected by admins.
+	managers := []interface{}{}
+	if cfg.UserInfo().IsAdmin {
+		var err error
+		if managers, err = credentialManagers(cfg); err != nil {
+			return err
+		}
+	}
+	if err := d.Set("credential_managers", managers); err != nil {
+		return fmt.Errorf("unable to set credential_managers field: %v", err)
+	}
+
 	return nil
 
 }
@@ -44,6 +124,46 @@ func dataServerInfo() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"cluster_name": {
+				Description: "Cluster name",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"external_url": {
+				Description: "External URL of the Concourse ATC/web server",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"feature_flags": {
+				Description: "Feature flags reported by the Concourse ATC/web server",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+			},
+			"credential_managers": {
+				Description: "Configured credential managers (only available to admins)",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Credential manager name, e.g. vault or credhub",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"info": {
+							Description: "Configuration and health of the credential manager (JSON)",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"health_error": {
+							Description: "Error reported by the health check of the credential manager",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
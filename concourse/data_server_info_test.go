@@ -0,0 +1,86 @@
+package concourse
+
+import (
+	"testing"
+)
+
+func TestDataServerInfo(t *testing.T) {
+	cases := []struct {
+		name     string
+		admin    bool
+		expected map[string]string
+	}{
+		{
+			name:  "admin",
+			admin: true,
+			expected: map[string]string{
+				"credential_managers.#":              "2",
+				"credential_managers.0.name":         "secretsmanager",
+				"credential_managers.0.health_error": "",
+				"credential_managers.0.info":         `{"region":"eu-west-1"}`,
+				"credential_managers.1.name":         "vault",
+				"credential_managers.1.health_error": "permission denied",
+				"credential_managers.1.info":         `{"health":{"error":"permission denied"},"url":"https://vault"}`,
+			},
+		},
+		{
+			name:  "not admin",
+			admin: false,
+			expected: map[string]string{
+				"credential_managers.#": "0",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		fake := newFakeATC()
+		fake.UserInfo.IsAdmin = c.admin
+		fake.Info = ServerInfo{
+			Version:       "6.1.0",
+			WorkerVersion: "2.2",
+			ExternalURL:   "https://ci.example.com",
+			ClusterName:   "production",
+			FeatureFlags:  map[string]bool{"global_resources": true, "redact_secrets": false},
+		}
+		fake.Creds = map[string]interface{}{
+			"vault": map[string]interface{}{
+				"url":    "https://vault",
+				"health": map[string]interface{}{"error": "permission denied"},
+			},
+			"secretsmanager": map[string]interface{}{"region": "eu-west-1"},
+		}
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		state, err := readData(t, dataServerInfo(), map[string]interface{}{}, cfg)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+
+		expected := map[string]string{
+			"version":                        "6.1.0",
+			"worker_version":                 "2.2",
+			"external_url":                   "https://ci.example.com",
+			"cluster_name":                   "production",
+			"feature_flags.%":                "2",
+			"feature_flags.global_resources": "true",
+			"feature_flags.redact_secrets":   "false",
+		}
+		for k, v := range c.expected {
+			expected[k] = v
+		}
+		for k, v := range expected {
+			if state.Attributes[k] != v {
+				t.Fatalf("%s: expected %s to be %q, got %q", c.name, k, v, state.Attributes[k])
+			}
+		}
+
+		creds := false
+		for _, request := range fake.Requests {
+			creds = creds || request == "GET /api/v1/info/creds"
+		}
+		if creds != c.admin {
+			t.Fatalf("%s: expected credential managers to be requested %v, got %v", c.name, c.admin, fake.Requests)
+		}
+	}
+}
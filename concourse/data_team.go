@@ -1,19 +1,76 @@
 package concourse
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
+func dataTeamRead(d *schema.ResourceData, m interface{}) error {
+	concourse := m.(Config).Concourse()
+
+	name := d.Get("name").(string)
+
+	teams, err := concourse.ListTeams()
+	if err != nil {
+		return fmt.Errorf("unable to list teams: %v", err)
+	}
+
+	for _, team := range teams {
+		if team.Name != name {
+			continue
+		}
+
+		pipelines, err := concourse.Team(team.Name).ListPipelines()
+		if err != nil {
+			return fmt.Errorf("unable to list pipelines of team \"%s\": %v", team.Name, err)
+		}
+		pipelineNames := make([]interface{}, len(pipelines))
+		for i, pipeline := range pipelines {
+			pipelineNames[i] = pipeline.Name
+		}
+
+		d.SetId(teamIDAsString(team.ID))
+
+		if err := d.Set("team_id", team.ID); err != nil {
+			return fmt.Errorf("unable to set team_id field: %v", err)
+		}
+
+		if err := d.Set("auth", flattenTeamAuth(team.Auth)); err != nil {
+			return fmt.Errorf("unable to set auth field: %v", err)
+		}
+
+		if err := d.Set("pipelines", pipelineNames); err != nil {
+			return fmt.Errorf("unable to set pipelines field: %v", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("team \"%s\" not found", name)
+}
+
 func dataTeam() *schema.Resource {
 	return &schema.Resource{
-		Read:   resourceTeamRead,
-		Exists: resourceTeamExists,
+		Read: dataTeamRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "Team name",
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"team_id": {
+				Description: "Numeric team ID",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"auth": teamAuthSchema(),
+			"pipelines": {
+				Description: "Names of the team's pipelines",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
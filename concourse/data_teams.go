@@ -0,0 +1,198 @@
+package concourse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// flattenTeamAuth converts the role based authentication configuration of a team into a list that can be
+// stored in a Terraform schema. Roles are sorted by name to keep the result stable.
+func flattenTeamAuth(auth atc.TeamAuth) []interface{} {
+	roles := make([]string, 0, len(auth))
+	for role := range auth {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	result := make([]interface{}, 0, len(roles))
+	for _, role := range roles {
+		users := make([]interface{}, 0, len(auth[role]["users"]))
+		for _, user := range auth[role]["users"] {
+			users = append(users, user)
+		}
+		groups := make([]interface{}, 0, len(auth[role]["groups"]))
+		for _, group := range auth[role]["groups"] {
+			groups = append(groups, group)
+		}
+		result = append(result, map[string]interface{}{
+			"role":       role,
+			"users":      users,
+			"groups":     groups,
+			"connectors": flattenTeamAuthConnectors(auth[role]),
+		})
+	}
+	return result
+}
+
+// flattenTeamAuthConnectors groups the users and groups of a role by their connector, e.g. "github:alice" is
+// listed as user "alice" of connector "github". Connectors are sorted by name to keep the result stable.
+func flattenTeamAuthConnectors(roleAuth map[string][]string) []interface{} {
+	byConnector := map[string]map[string][]interface{}{}
+	for _, kind := range []string{"users", "groups"} {
+		for _, entry := range roleAuth[kind] {
+			connector, name := "", entry
+			if parts := strings.SplitN(entry, ":", 2); len(parts) == 2 {
+				connector, name = parts[0], parts[1]
+			}
+			if _, ok := byConnector[connector]; !ok {
+				byConnector[connector] = map[string][]interface{}{
+					"users":  {},
+					"groups": {},
+				}
+			}
+			byConnector[connector][kind] = append(byConnector[connector][kind], name)
+		}
+	}
+
+	connectors := make([]string, 0, len(byConnector))
+	for connector := range byConnector {
+		connectors = append(connectors, connector)
+	}
+	sort.Strings(connectors)
+
+	result := make([]interface{}, len(connectors))
+	for i, connector := range connectors {
+		result[i] = map[string]interface{}{
+			"connector": connector,
+			"users":     byConnector[connector]["users"],
+			"groups":    byConnector[connector]["groups"],
+		}
+	}
+	return result
+}
+
+func dataTeamsRead(d *schema.ResourceData, m interface{}) error {
+	concourse := m.(Config).Concourse()
+
+	teams, err := concourse.ListTeams()
+	if err != nil {
+		return fmt.Errorf("unable to list teams: %v", err)
+	}
+
+	names := make([]interface{}, len(teams))
+	result := make([]interface{}, len(teams))
+	for i, team := range teams {
+		names[i] = team.Name
+		result[i] = map[string]interface{}{
+			"id":   team.ID,
+			"name": team.Name,
+			"auth": flattenTeamAuth(team.Auth),
+		}
+	}
+
+	d.SetId(concourse.URL())
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("unable to set names field: %v", err)
+	}
+
+	if err := d.Set("teams", result); err != nil {
+		return fmt.Errorf("unable to set teams field: %v", err)
+	}
+
+	return nil
+}
+
+// teamAuthSchema describes the computed, role based authentication configuration of a team.
+func teamAuthSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Role based authentication configuration",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role": {
+					Description: "Role name",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"users": {
+					Description: "Users that have been granted the role",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"groups": {
+					Description: "Groups that have been granted the role",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"connectors": {
+					Description: "Users and groups that have been granted the role, grouped by connector",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"connector": {
+								Description: "Connector name, e.g. github or local",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"users": {
+								Description: "Users of the connector (without connector prefix)",
+								Type:        schema.TypeList,
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"groups": {
+								Description: "Groups of the connector (without connector prefix)",
+								Type:        schema.TypeList,
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataTeams() *schema.Resource {
+	return &schema.Resource{
+		Read: dataTeamsRead,
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Description: "Names of all teams",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"teams": {
+				Description: "All teams",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Numeric team ID",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Team name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"auth": teamAuthSchema(),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package concourse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestFlattenTeamAuth(t *testing.T) {
+	auth := atc.TeamAuth{
+		"viewer": {
+			"users": {"local:bob"},
+		},
+		"owner": {
+			"users":  {"github:alice", "local:admin"},
+			"groups": {"github:org:team"},
+		},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"role":   "owner",
+			"users":  []interface{}{"github:alice", "local:admin"},
+			"groups": []interface{}{"github:org:team"},
+			"connectors": []interface{}{
+				map[string]interface{}{
+					"connector": "github",
+					"users":     []interface{}{"alice"},
+					"groups":    []interface{}{"org:team"},
+				},
+				map[string]interface{}{
+					"connector": "local",
+					"users":     []interface{}{"admin"},
+					"groups":    []interface{}{},
+				},
+			},
+		},
+		map[string]interface{}{
+			"role":   "viewer",
+			"users":  []interface{}{"local:bob"},
+			"groups": []interface{}{},
+			"connectors": []interface{}{
+				map[string]interface{}{
+					"connector": "local",
+					"users":     []interface{}{"bob"},
+					"groups":    []interface{}{},
+				},
+			},
+		},
+	}
+
+	if actual := flattenTeamAuth(auth); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,200 @@
+package concourse
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// workerFilter selects workers by their attributes. Empty fields match every worker.
+type workerFilter struct {
+	Platform string
+	Tags     []string
+	Team     string
+	State    string
+}
+
+// matches reports whether the given worker satisfies the filter. A worker has to advertise all tags of the filter.
+func (f workerFilter) matches(worker atc.Worker) bool {
+	if f.Platform != "" && worker.Platform != f.Platform {
+		return false
+	}
+	if f.Team != "" && worker.Team != f.Team {
+		return false
+	}
+	if f.State != "" && worker.State != f.State {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !containsString(worker.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenWorker(worker atc.Worker) map[string]interface{} {
+	tags := make([]interface{}, len(worker.Tags))
+	for i, tag := range worker.Tags {
+		tags[i] = tag
+	}
+	resourceTypes := make([]interface{}, len(worker.ResourceTypes))
+	for i, resourceType := range worker.ResourceTypes {
+		resourceTypes[i] = resourceType.Type
+	}
+	return map[string]interface{}{
+		"name":              worker.Name,
+		"platform":          worker.Platform,
+		"tags":              tags,
+		"team":              worker.Team,
+		"state":             worker.State,
+		"version":           worker.Version,
+		"active_containers": worker.ActiveContainers,
+		"active_volumes":    worker.ActiveVolumes,
+		"resource_types":    resourceTypes,
+	}
+}
+
+func dataWorkersRead(d *schema.ResourceData, m interface{}) error {
+	concourse := m.(Config).Concourse()
+
+	filter := workerFilter{
+		Platform: d.Get("platform").(string),
+		Tags:     expandStringList(d.Get("tags").([]interface{})),
+		Team:     d.Get("team").(string),
+		State:    d.Get("state").(string),
+	}
+
+	workers, err := concourse.ListWorkers()
+	if err != nil {
+		return fmt.Errorf("unable to list workers: %v", err)
+	}
+
+	names := make([]interface{}, 0, len(workers))
+	result := make([]interface{}, 0, len(workers))
+	for _, worker := range workers {
+		if !filter.matches(worker) {
+			continue
+		}
+		names = append(names, worker.Name)
+		result = append(result, flattenWorker(worker))
+	}
+
+	d.SetId(concourse.URL())
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("unable to set names field: %v", err)
+	}
+
+	if err := d.Set("workers", result); err != nil {
+		return fmt.Errorf("unable to set workers field: %v", err)
+	}
+
+	return nil
+}
+
+// workerSchema describes the computed attributes written by flattenWorker.
+func workerSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Worker name",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"platform": {
+				Description: "Platform",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"tags": {
+				Description: "Tags",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"team": {
+				Description: "Team the worker is scoped to",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"state": {
+				Description: "State",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"version": {
+				Description: "Worker version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"active_containers": {
+				Description: "Number of active containers",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"active_volumes": {
+				Description: "Number of active volumes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"resource_types": {
+				Description: "Names of the resource types provided by the worker",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataWorkers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataWorkersRead,
+		Schema: map[string]*schema.Schema{
+			"platform": {
+				Description: "Only list workers with the given platform",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tags": {
+				Description: "Only list workers that have all of the given tags",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"team": {
+				Description: "Only list workers scoped to the given team",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"state": {
+				Description: "Only list workers in the given state",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"names": {
+				Description: "Names of all matching workers",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"workers": {
+				Description: "All matching workers",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        workerSchema(),
+			},
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package concourse
+
+import (
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestWorkerFilter_Matches(t *testing.T) {
+	worker := atc.Worker{
+		Name:     "gpu-1",
+		Platform: "linux",
+		Tags:     []string{"gpu", "large"},
+		Team:     "ml",
+		State:    "running",
+	}
+
+	cases := []struct {
+		name     string
+		filter   workerFilter
+		expected bool
+	}{
+		{"empty filter", workerFilter{}, true},
+		{"matching platform", workerFilter{Platform: "linux"}, true},
+		{"other platform", workerFilter{Platform: "windows"}, false},
+		{"subset of tags", workerFilter{Tags: []string{"gpu"}}, true},
+		{"missing tag", workerFilter{Tags: []string{"gpu", "arm"}}, false},
+		{"matching team", workerFilter{Team: "ml"}, true},
+		{"other team", workerFilter{Team: "main"}, false},
+		{"other state", workerFilter{State: "stalled"}, false},
+		{"all attributes", workerFilter{Platform: "linux", Tags: []string{"large"}, Team: "ml", State: "running"}, true},
+	}
+
+	for _, c := range cases {
+		if actual := c.filter.matches(worker); actual != c.expected {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
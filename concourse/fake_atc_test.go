@@ -0,0 +1,720 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// fakeATC is a minimal in-memory implementation of the ATC API endpoints used by the resources and data sources
+// of this provider. It allows them to be tested against a real Concourse client.
+type fakeATC struct {
+	mu sync.Mutex
+
+	UserInfo  SkyUserInfo
+	Info      ServerInfo
+	Creds     map[string]interface{}
+	Teams     []atc.Team
+	Pipelines map[string][]atc.Pipeline
+	Configs   map[string]atc.Config
+	Versions  map[string]int
+	Checks    map[int]atc.Check
+	Jobs      map[string][]atc.Job
+	Builds    []atc.Build
+	Workers   []atc.Worker
+	Pins      map[string]atc.Resource
+
+	ResourceVersions map[string][]atc.ResourceVersion
+
+	// Errors makes requests ("<method> <path>") fail with the given status code.
+	Errors map[string]int
+
+	Requests []string
+	Bodies   map[string]string
+
+	nextID int
+}
+
+func newFakeATC() *fakeATC {
+	return &fakeATC{
+		UserInfo:  SkyUserInfo{UserID: "admin", UserName: "admin", IsAdmin: true},
+		Info:      ServerInfo{Version: "6.0.0", WorkerVersion: "2.2"},
+		Pipelines: map[string][]atc.Pipeline{},
+		Configs:   map[string]atc.Config{},
+		Versions:  map[string]int{},
+		Checks:    map[int]atc.Check{},
+		Jobs:      map[string][]atc.Job{},
+		Pins:      map[string]atc.Resource{},
+
+		ResourceVersions: map[string][]atc.ResourceVersion{},
+
+		Errors: map[string]int{},
+		Bodies: map[string]string{},
+		nextID: 100,
+	}
+}
+
+// Start starts serving the fake ATC and returns a provider configuration that is connected to it, as well as a
+// function that stops the server.
+func (f *fakeATC) Start(t *testing.T) (Config, func()) {
+	server := httptest.NewServer(f)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		server.Close()
+		t.Fatalf("unable to parse URL of fake ATC: %v", err)
+	}
+	cfg, err := NewConfig(u, server.Client(), false, "")
+	if err != nil {
+		server.Close()
+		t.Fatalf("unable to configure provider for fake ATC: %v", err)
+	}
+	return cfg, server.Close
+}
+
+// Team returns the team with the given name.
+func (f *fakeATC) Team(name string) (atc.Team, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, team := range f.Teams {
+		if team.Name == name {
+			return team, true
+		}
+	}
+	return atc.Team{}, false
+}
+
+// AddTeam adds a team with a generated ID and returns that ID.
+func (f *fakeATC) AddTeam(name string, auth atc.TeamAuth) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	f.Teams = append(f.Teams, atc.Team{ID: f.nextID, Name: name, Auth: auth})
+	return f.nextID
+}
+
+// AddPipeline adds a pipeline with a generated ID to a team.
+func (f *fakeATC) AddPipeline(team, name string, config atc.Config) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	f.Pipelines[team] = append(f.Pipelines[team], atc.Pipeline{ID: f.nextID, Name: name, TeamName: team})
+	f.Configs[team+"/"+name] = config
+	f.Versions[team+"/"+name] = 1
+}
+
+// Pipeline returns the pipeline with the given name of a team.
+func (f *fakeATC) Pipeline(team, name string) (atc.Pipeline, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i := f.pipelineIndex(team, name); i >= 0 {
+		return f.Pipelines[team][i], true
+	}
+	return atc.Pipeline{}, false
+}
+
+// PipelineNames returns the names of the pipelines of a team in dashboard order.
+func (f *fakeATC) PipelineNames(team string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for _, pipeline := range f.Pipelines[team] {
+		names = append(names, pipeline.Name)
+	}
+	return names
+}
+
+// AddJob adds a job to a pipeline.
+func (f *fakeATC) AddJob(team, pipeline string, job atc.Job) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	job.ID = f.nextID
+	job.TeamName, job.PipelineName = team, pipeline
+	f.Jobs[team+"/"+pipeline] = append(f.Jobs[team+"/"+pipeline], job)
+}
+
+// Job returns a job of a pipeline.
+func (f *fakeATC) Job(team, pipeline, name string) (atc.Job, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, job := range f.Jobs[team+"/"+pipeline] {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return atc.Job{}, false
+}
+
+// AddResourceVersion adds an enabled version to a resource and returns it.
+func (f *fakeATC) AddResourceVersion(team, pipeline, resource string, version atc.Version) atc.ResourceVersion {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := team + "/" + pipeline + "/" + resource
+	f.nextID++
+	v := atc.ResourceVersion{ID: f.nextID, Version: version, Enabled: true}
+	f.ResourceVersions[key] = append(f.ResourceVersions[key], v)
+	return v
+}
+
+// ResourceVersion returns a version of a resource by its ID.
+func (f *fakeATC) ResourceVersion(team, pipeline, resource string, id int) (atc.ResourceVersion, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, v := range f.ResourceVersions[team+"/"+pipeline+"/"+resource] {
+		if v.ID == id {
+			return v, true
+		}
+	}
+	return atc.ResourceVersion{}, false
+}
+
+// AddBuild adds a build of a job with the given status and returns it.
+func (f *fakeATC) AddBuild(team, pipeline, job string, status atc.BuildStatus) atc.Build {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addBuild(team, pipeline, job, status)
+}
+
+func (f *fakeATC) addBuild(team, pipeline, job string, status atc.BuildStatus) atc.Build {
+	number := 1
+	for _, build := range f.Builds {
+		if build.TeamName == team && build.PipelineName == pipeline && build.JobName == job {
+			number++
+		}
+	}
+	f.nextID++
+	build := atc.Build{
+		ID:           f.nextID,
+		Name:         strconv.Itoa(number),
+		Status:       string(status),
+		TeamName:     team,
+		PipelineName: pipeline,
+		JobName:      job,
+	}
+	f.Builds = append(f.Builds, build)
+	return build
+}
+
+// SetBuildStatus changes the status of a build.
+func (f *fakeATC) SetBuildStatus(id int, status atc.BuildStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.Builds {
+		if f.Builds[i].ID == id {
+			f.Builds[i].Status = string(status)
+		}
+	}
+}
+
+func (f *fakeATC) pipelineIndex(team, name string) int {
+	for i, pipeline := range f.Pipelines[team] {
+		if pipeline.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeATC) teamIndex(name string) int {
+	for i, team := range f.Teams {
+		if team.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeATC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Requests = append(f.Requests, r.Method+" "+r.URL.Path)
+
+	body, _ := ioutil.ReadAll(r.Body)
+	f.Bodies[r.Method+" "+r.URL.Path] = string(body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if status, ok := f.Errors[r.Method+" "+r.URL.Path]; ok {
+		w.WriteHeader(status)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	route := r.Method + " " + strings.Join(parts, "/")
+
+	switch {
+	case route == "GET api/v1/info":
+		writeJSON(w, http.StatusOK, f.Info)
+
+	case route == "GET api/v1/info/creds":
+		if !f.UserInfo.IsAdmin {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		writeJSON(w, http.StatusOK, f.Creds)
+
+	case route == "GET sky/userinfo":
+		writeJSON(w, http.StatusOK, f.UserInfo)
+
+	case r.Method == "GET" && len(parts) == 4 && parts[2] == "checks":
+		id, _ := strconv.Atoi(parts[3])
+		check, ok := f.Checks[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, check)
+
+	case r.Method == "GET" && len(parts) == 4 && parts[2] == "builds":
+		for _, build := range f.Builds {
+			if strconv.Itoa(build.ID) == parts[3] {
+				writeJSON(w, http.StatusOK, build)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+
+	case r.Method == "GET" && len(parts) == 5 && parts[2] == "teams" && parts[4] == "builds":
+		f.serveBuilds(w, r, func(b atc.Build) bool { return b.TeamName == parts[3] })
+
+	case route == "GET api/v1/workers":
+		workers := f.Workers
+		if workers == nil {
+			workers = []atc.Worker{}
+		}
+		writeJSON(w, http.StatusOK, workers)
+
+	case r.Method == "PUT" && len(parts) == 5 && parts[2] == "workers":
+		for i, worker := range f.Workers {
+			if worker.Name != parts[3] {
+				continue
+			}
+			switch parts[4] {
+			case "land":
+				f.Workers[i].State = "landing"
+			case "retire":
+				f.Workers[i].State = "retiring"
+			case "prune":
+				f.Workers = append(f.Workers[:i], f.Workers[i+1:]...)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+
+	case route == "GET api/v1/pipelines":
+		pipelines := []atc.Pipeline{}
+		for _, team := range f.Teams {
+			pipelines = append(pipelines, f.Pipelines[team.Name]...)
+		}
+		writeJSON(w, http.StatusOK, pipelines)
+
+	case route == "GET api/v1/teams":
+		writeJSON(w, http.StatusOK, f.Teams)
+
+	case r.Method == "GET" && len(parts) == 4 && parts[2] == "teams":
+		i := f.teamIndex(parts[3])
+		if i < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, f.Teams[i])
+
+	case r.Method == "PUT" && len(parts) == 4 && parts[2] == "teams":
+		var team atc.Team
+		if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		team.Name = parts[3]
+		if i := f.teamIndex(team.Name); i >= 0 {
+			team.ID = f.Teams[i].ID
+			f.Teams[i] = team
+			writeJSON(w, http.StatusOK, team)
+			return
+		}
+		f.nextID++
+		team.ID = f.nextID
+		f.Teams = append(f.Teams, team)
+		writeJSON(w, http.StatusCreated, team)
+
+	case r.Method == "PUT" && len(parts) == 5 && parts[2] == "teams" && parts[4] == "rename":
+		var rename atc.RenameRequest
+		if err := json.NewDecoder(r.Body).Decode(&rename); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		i := f.teamIndex(parts[3])
+		if i < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.Teams[i].Name = rename.NewName
+		f.Pipelines[rename.NewName] = f.Pipelines[parts[3]]
+		delete(f.Pipelines, parts[3])
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == "DELETE" && len(parts) == 4 && parts[2] == "teams":
+		i := f.teamIndex(parts[3])
+		if i < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.Teams = append(f.Teams[:i], f.Teams[i+1:]...)
+		delete(f.Pipelines, parts[3])
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == "GET" && len(parts) == 5 && parts[2] == "teams" && parts[4] == "pipelines":
+		pipelines := f.Pipelines[parts[3]]
+		if pipelines == nil {
+			pipelines = []atc.Pipeline{}
+		}
+		writeJSON(w, http.StatusOK, pipelines)
+
+	case r.Method == "PUT" && len(parts) == 6 && parts[2] == "teams" && parts[5] == "ordering":
+		var names []string
+		if err := json.NewDecoder(r.Body).Decode(&names); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var ordered []atc.Pipeline
+		for _, name := range names {
+			if i := f.pipelineIndex(parts[3], name); i >= 0 {
+				ordered = append(ordered, f.Pipelines[parts[3]][i])
+			}
+		}
+		for _, pipeline := range f.Pipelines[parts[3]] {
+			if !containsString(names, pipeline.Name) {
+				ordered = append(ordered, pipeline)
+			}
+		}
+		f.Pipelines[parts[3]] = ordered
+		w.WriteHeader(http.StatusOK)
+
+	case len(parts) >= 6 && parts[2] == "teams" && parts[4] == "pipelines":
+		f.servePipeline(w, r, parts[3], parts[5], strings.Join(parts[6:], "/"))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeATC) servePipeline(w http.ResponseWriter, r *http.Request, team, name, action string) {
+	key := team + "/" + name
+	i := f.pipelineIndex(team, name)
+
+	if r.Method == "PUT" && action == "config" {
+		body, _ := ioutil.ReadAll(r.Body)
+		var config atc.Config
+		if err := atc.UnmarshalConfig(body, &config); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(atc.SaveConfigResponse{Errors: []string{err.Error()}})
+			return
+		}
+		if r.Header.Get(atc.ConfigVersionHeader) != strconv.Itoa(f.Versions[key]) && i >= 0 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.Configs[key] = config
+		f.Versions[key]++
+		if i >= 0 {
+			writeJSON(w, http.StatusOK, atc.SaveConfigResponse{})
+			return
+		}
+		f.nextID++
+		f.Pipelines[team] = append(f.Pipelines[team], atc.Pipeline{ID: f.nextID, Name: name, TeamName: team, Paused: true})
+		writeJSON(w, http.StatusCreated, atc.SaveConfigResponse{})
+		return
+	}
+
+	if i < 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	pipeline := &f.Pipelines[team][i]
+
+	// Checks succeed right away, so that tests do not have to wait for them.
+	if r.Method == "POST" && strings.HasSuffix(action, "/check") {
+		kind, resource := path.Split(strings.TrimSuffix(action, "/check"))
+		found := false
+		for _, v := range f.Configs[key].Resources {
+			found = found || (kind == "resources/" && v.Name == resource)
+		}
+		for _, v := range f.Configs[key].ResourceTypes {
+			found = found || (kind == "resource-types/" && v.Name == resource)
+		}
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.nextID++
+		f.Checks[f.nextID] = atc.Check{ID: f.nextID, Status: "succeeded"}
+		writeJSON(w, http.StatusCreated, f.Checks[f.nextID])
+		return
+	}
+
+	if strings.HasPrefix(action, "resources/") {
+		resourceParts := strings.SplitN(strings.TrimPrefix(action, "resources/"), "/", 2)
+		f.serveResource(w, r, team, name, resourceParts[0], strings.Join(resourceParts[1:], "/"))
+		return
+	}
+
+	if strings.HasPrefix(action, "jobs/") {
+		jobParts := strings.SplitN(strings.TrimPrefix(action, "jobs/"), "/", 2)
+		f.serveJob(w, r, team, name, jobParts[0], strings.Join(jobParts[1:], "/"))
+		return
+	}
+
+	switch r.Method + " " + action {
+	case "GET jobs":
+		jobs := f.Jobs[key]
+		if jobs == nil {
+			jobs = []atc.Job{}
+		}
+		writeJSON(w, http.StatusOK, jobs)
+	case "GET builds":
+		f.serveBuilds(w, r, func(b atc.Build) bool { return b.TeamName == team && b.PipelineName == name })
+	case "GET resources":
+		resources := []atc.Resource{}
+		for _, v := range f.Configs[key].Resources {
+			resources = append(resources, atc.Resource{Name: v.Name, PipelineName: name, TeamName: team, Type: v.Type})
+		}
+		writeJSON(w, http.StatusOK, resources)
+	case "GET ":
+		writeJSON(w, http.StatusOK, pipeline)
+	case "GET config":
+		w.Header().Set(atc.ConfigVersionHeader, strconv.Itoa(f.Versions[key]))
+		writeJSON(w, http.StatusOK, atc.ConfigResponse{Config: f.Configs[key]})
+	case "DELETE ":
+		f.Pipelines[team] = append(f.Pipelines[team][:i], f.Pipelines[team][i+1:]...)
+		delete(f.Configs, key)
+		delete(f.Versions, key)
+		w.WriteHeader(http.StatusNoContent)
+	case "PUT rename":
+		var rename atc.RenameRequest
+		if err := json.NewDecoder(r.Body).Decode(&rename); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		newKey := team + "/" + rename.NewName
+		pipeline.Name = rename.NewName
+		f.Configs[newKey], f.Versions[newKey] = f.Configs[key], f.Versions[key]
+		delete(f.Configs, key)
+		delete(f.Versions, key)
+		w.WriteHeader(http.StatusNoContent)
+	case "PUT pause", "PUT unpause":
+		pipeline.Paused = action == "pause"
+		w.WriteHeader(http.StatusOK)
+	case "PUT expose", "PUT hide":
+		pipeline.Public = action == "expose"
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeATC) serveJob(w http.ResponseWriter, r *http.Request, team, pipeline, name, action string) {
+	key := team + "/" + pipeline
+	var job *atc.Job
+	for i := range f.Jobs[key] {
+		if f.Jobs[key][i].Name == name {
+			job = &f.Jobs[key][i]
+		}
+	}
+	if job == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method + " " + action {
+	case "GET ":
+		writeJSON(w, http.StatusOK, job)
+	case "PUT pause", "PUT unpause":
+		job.Paused = action == "pause"
+		w.WriteHeader(http.StatusOK)
+	case "GET builds":
+		f.serveBuilds(w, r, func(b atc.Build) bool {
+			return b.TeamName == team && b.PipelineName == pipeline && b.JobName == name
+		})
+	case "POST builds":
+		writeJSON(w, http.StatusOK, f.addBuild(team, pipeline, name, atc.StatusPending))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeATC) serveResource(w http.ResponseWriter, r *http.Request, team, pipeline, name, action string) {
+	key := team + "/" + pipeline + "/" + name
+	found := false
+	for _, v := range f.Configs[team+"/"+pipeline].Resources {
+		found = found || v.Name == name
+	}
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	resource := f.Pins[key]
+	resource.Name, resource.PipelineName, resource.TeamName = name, pipeline, team
+
+	if r.Method == "PUT" && strings.HasPrefix(action, "versions/") {
+		versionParts := strings.Split(action, "/")
+		versions := f.ResourceVersions[key]
+		for i := range versions {
+			if len(versionParts) != 3 || strconv.Itoa(versions[i].ID) != versionParts[1] {
+				continue
+			}
+			switch versionParts[2] {
+			case "enable", "disable":
+				versions[i].Enabled = versionParts[2] == "enable"
+			case "pin":
+				resource.PinnedVersion = versions[i].Version
+				f.Pins[key] = resource
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method + " " + action {
+	case "GET ":
+		writeJSON(w, http.StatusOK, resource)
+	case "GET versions":
+		// Like the ATC, versions are filtered by the given fields and listed newest first.
+		filter := atc.Version{}
+		for _, field := range r.URL.Query()["filter"] {
+			kv := strings.SplitN(field, ":", 2)
+			if len(kv) == 2 {
+				filter[kv[0]] = kv[1]
+			}
+		}
+		versions := []atc.ResourceVersion{}
+		ids := []int{}
+		for i := len(f.ResourceVersions[key]) - 1; i >= 0; i-- {
+			if v := f.ResourceVersions[key][i]; versionMatches(v.Version, filter) {
+				versions = append(versions, v)
+				ids = append(ids, v.ID)
+			}
+		}
+		start, end := servePage(w, r, ids)
+		writeJSON(w, http.StatusOK, versions[start:end])
+	case "PUT unpin":
+		resource.PinnedVersion = nil
+		resource.PinComment = ""
+		f.Pins[key] = resource
+		w.WriteHeader(http.StatusOK)
+	case "PUT pin_comment":
+		var body atc.SetPinCommentRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resource.PinComment = body.PinComment
+		f.Pins[key] = resource
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// serveBuilds lists the matching builds, newest first, and pages through them the way the ATC does: the next
+// page contains the builds older than "since", the previous page the builds newer than "until".
+func (f *fakeATC) serveBuilds(w http.ResponseWriter, r *http.Request, match func(atc.Build) bool) {
+	builds := []atc.Build{}
+	ids := []int{}
+	for i := len(f.Builds) - 1; i >= 0; i-- {
+		if match(f.Builds[i]) {
+			builds = append(builds, f.Builds[i])
+			ids = append(ids, f.Builds[i].ID)
+		}
+	}
+	start, end := servePage(w, r, ids)
+	writeJSON(w, http.StatusOK, builds[start:end])
+}
+
+// servePage selects a page of the given IDs, which are sorted in descending order, and sets the Link headers of the
+// next and previous page.
+func servePage(w http.ResponseWriter, r *http.Request, ids []int) (int, int) {
+	query := r.URL.Query()
+	since, _ := strconv.Atoi(query.Get("since"))
+	until, _ := strconv.Atoi(query.Get("until"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit == 0 {
+		limit = 100
+	}
+
+	start := 0
+	for since != 0 && start < len(ids) && ids[start] >= since {
+		start++
+	}
+	end := len(ids)
+	if until != 0 {
+		end = start
+		for end < len(ids) && ids[end] > until {
+			end++
+		}
+		if end-start > limit {
+			start = end - limit
+		}
+	} else if end-start > limit {
+		end = start + limit
+	}
+
+	if end > start && end < len(ids) {
+		w.Header().Add("Link", fmt.Sprintf(`<%s?since=%d&limit=%d>; rel="next"`, r.URL.Path, ids[end-1], limit))
+	}
+	if end > start && start > 0 {
+		w.Header().Add("Link", fmt.Sprintf(`<%s?until=%d&limit=%d>; rel="previous"`, r.URL.Path, ids[start], limit))
+	}
+	return start, end
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// applyResource plans and applies the given raw configuration on top of the given state, just like Terraform
+// would do it.
+func applyResource(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) (*terraform.InstanceState, error) {
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		return nil, err
+	}
+	if diff == nil {
+		return state, nil
+	}
+	return r.Apply(state, diff, meta)
+}
+
+// readData plans and reads a data source with the given raw configuration, just like Terraform would do it.
+func readData(t *testing.T, r *schema.Resource, raw map[string]interface{}, meta interface{}) (*terraform.InstanceState, error) {
+	diff, err := r.Diff(nil, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		return nil, err
+	}
+	return r.ReadDataApply(diff, meta)
+}
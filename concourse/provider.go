@@ -84,13 +84,29 @@ func Provider() terraform.ResourceProvider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"concourse_team":     resourceTeam(),
-			"concourse_pipeline": resourcePipeline(),
+			"concourse_team":                   resourceTeam(),
+			"concourse_team_member":            resourceTeamMember(),
+			"concourse_team_pipelines":         resourceTeamPipelines(),
+			"concourse_pipeline":               resourcePipeline(),
+			"concourse_pipeline_order":         resourcePipelineOrder(),
+			"concourse_job":                    resourceJob(),
+			"concourse_build":                  resourceBuild(),
+			"concourse_resource_check":         resourceResourceCheck(),
+			"concourse_worker_action":          resourceWorkerAction(),
+			"concourse_resource_version_pin":   resourceResourceVersionPin(),
+			"concourse_resource_version_state": resourceResourceVersionState(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"concourse_caller_identity": dataCallerIdentity(),
-			"concourse_server_info":     dataServerInfo(),
-			"concourse_team":            dataTeam(),
+			"concourse_caller_identity":   dataCallerIdentity(),
+			"concourse_job":               dataJob(),
+			"concourse_job_builds":        dataJobBuilds(),
+			"concourse_pipelines":         dataPipelines(),
+			"concourse_resource_versions": dataResourceVersions(),
+			"concourse_resource_webhook":  dataResourceWebhook(),
+			"concourse_server_info":       dataServerInfo(),
+			"concourse_team":              dataTeam(),
+			"concourse_teams":             dataTeams(),
+			"concourse_workers":           dataWorkers(),
 		},
 		ConfigureFunc: configure(),
 	}
@@ -0,0 +1,221 @@
+package concourse
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// buildPollInterval is the interval in which the status of a build is polled once its event stream has ended.
+var buildPollInterval = 2 * time.Second
+
+func setBuild(d *schema.ResourceData, build atc.Build) {
+	d.Set("build_id", build.ID)
+	d.Set("name", build.Name)
+	d.Set("status", build.Status)
+	d.Set("start_time", int(build.StartTime))
+	d.Set("end_time", int(build.EndTime))
+}
+
+// streamBuildEvents writes the log output of a build into the Terraform log until the event stream ends.
+func streamBuildEvents(events concourse.Events, build atc.Build) error {
+	prefix := fmt.Sprintf("%s/%s #%s", build.PipelineName, build.JobName, build.Name)
+	for {
+		ev, err := events.NextEvent()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch e := ev.(type) {
+		case event.Log:
+			for _, line := range strings.Split(strings.TrimRight(e.Payload, "\n"), "\n") {
+				log.Printf("[INFO] %s: %s", prefix, line)
+			}
+		case event.Error:
+			log.Printf("[ERROR] %s: %s", prefix, e.Message)
+		case event.Status:
+			log.Printf("[INFO] %s: %s", prefix, e.Status)
+			if e.Status != atc.StatusStarted && e.Status != atc.StatusPending {
+				return nil
+			}
+		}
+	}
+}
+
+// waitForBuild blocks until the given build has finished or the timeout has been reached.
+func waitForBuild(client concourse.Client, build atc.Build, timeout time.Duration) (atc.Build, error) {
+	deadline := time.Now().Add(timeout)
+
+	// The event stream is only used for logging, the final status is always fetched via the API.
+	if events, err := client.BuildEvents(strconv.Itoa(build.ID)); err != nil {
+		log.Printf("[WARN] unable to stream events of build %d: %v", build.ID, err)
+	} else {
+		streamed := make(chan error, 1)
+		go func() {
+			streamed <- streamBuildEvents(events, build)
+		}()
+
+		select {
+		case err := <-streamed:
+			if err != nil {
+				log.Printf("[WARN] unable to stream events of build %d: %v", build.ID, err)
+			}
+		case <-time.After(timeout):
+		}
+		events.Close()
+	}
+
+	for {
+		current, found, err := client.Build(strconv.Itoa(build.ID))
+		if err != nil {
+			return build, fmt.Errorf("unable to fetch build %d: %v", build.ID, err)
+		}
+		if !found {
+			return build, fmt.Errorf("build %d not found", build.ID)
+		}
+		if !current.IsRunning() {
+			return current, nil
+		}
+		if time.Now().After(deadline) {
+			return current, fmt.Errorf("timed out after %s waiting for build %d to finish (status: %s)", timeout, build.ID, current.Status)
+		}
+		time.Sleep(buildPollInterval)
+	}
+}
+
+func resourceBuildCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Config).Concourse()
+
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	job := d.Get("job").(string)
+
+	build, err := client.Team(team).CreateJobBuild(pipeline, job)
+	if err != nil {
+		return fmt.Errorf("unable to trigger job \"%s\" of pipeline \"%s\" in team \"%s\": %v", job, pipeline, team, err)
+	}
+
+	d.SetId(strconv.Itoa(build.ID))
+	setBuild(d, build)
+
+	if !d.Get("wait").(bool) {
+		return nil
+	}
+
+	build, err = waitForBuild(client, build, d.Timeout(schema.TimeoutCreate))
+	setBuild(d, build)
+	if err != nil {
+		return err
+	}
+	if atc.BuildStatus(build.Status) != atc.StatusSucceeded {
+		return fmt.Errorf("build %s of job \"%s\" in pipeline \"%s\" did not succeed (status: %s)", build.Name, job, pipeline, build.Status)
+	}
+
+	return nil
+}
+
+func resourceBuildRead(d *schema.ResourceData, m interface{}) error {
+	build, found, err := m.(Config).Concourse().Build(d.Id())
+	if err != nil {
+		return fmt.Errorf("unable to fetch build %s: %v", d.Id(), err)
+	}
+
+	// If the build cannot be found, it has probably been deleted along with its pipeline.
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	setBuild(d, build)
+	return nil
+}
+
+func resourceBuildUpdate(d *schema.ResourceData, m interface{}) error {
+	// Only "wait" can be changed without triggering a new build, which is not relevant after creation.
+	return resourceBuildRead(d, m)
+}
+
+func resourceBuildDelete(d *schema.ResourceData, m interface{}) error {
+	// Builds are part of the history of a job and cannot be deleted, so we only drop them from the state.
+	return nil
+}
+
+func resourceBuild() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuildCreate,
+		Read:   resourceBuildRead,
+		Update: resourceBuildUpdate,
+		Delete: resourceBuildDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pipeline": {
+				Description: "Pipeline name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"job": {
+				Description: "Job name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary values that trigger a new build when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"wait": {
+				Description: "Wait for the build to finish and fail if it does not succeed",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"build_id": {
+				Description: "Build ID",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"name": {
+				Description: "Build name (build number)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Build status",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"start_time": {
+				Description: "Start time of the build (unix timestamp)",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"end_time": {
+				Description: "End time of the build (unix timestamp)",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
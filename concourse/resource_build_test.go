@@ -0,0 +1,98 @@
+package concourse
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestWaitForBuild(t *testing.T) {
+	defer func(interval time.Duration) { buildPollInterval = interval }(buildPollInterval)
+	buildPollInterval = 10 * time.Millisecond
+
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	fake.AddPipeline("main", "p1", atc.Config{})
+	fake.AddJob("main", "p1", atc.Job{Name: "deploy"})
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	client := cfg.Concourse()
+
+	// Finished builds are returned right away.
+	succeeded := fake.AddBuild("main", "p1", "deploy", atc.StatusSucceeded)
+	build, err := waitForBuild(client, succeeded, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if build.Status != string(atc.StatusSucceeded) {
+		t.Fatalf("expected succeeded build, got %s", build.Status)
+	}
+
+	// Builds that finish while waiting are picked up by polling.
+	started := fake.AddBuild("main", "p1", "deploy", atc.StatusStarted)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		fake.SetBuildStatus(started.ID, atc.StatusFailed)
+	}()
+	build, err = waitForBuild(client, started, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if build.Status != string(atc.StatusFailed) {
+		t.Fatalf("expected failed build, got %s", build.Status)
+	}
+
+	// Builds that do not finish in time fail once the timeout has been reached.
+	running := fake.AddBuild("main", "p1", "deploy", atc.StatusStarted)
+	start := time.Now()
+	build, err = waitForBuild(client, running, 100*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected timeout to be enforced, waited %s", elapsed)
+	}
+	if build.Status != string(atc.StatusStarted) {
+		t.Fatalf("expected last known status to be returned, got %s", build.Status)
+	}
+}
+
+func TestResourceBuildNoWait(t *testing.T) {
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	fake.AddPipeline("main", "p1", atc.Config{})
+	fake.AddJob("main", "p1", atc.Job{Name: "deploy"})
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourceBuild()
+	state, err := applyResource(t, r, nil, map[string]interface{}{
+		"team":     "main",
+		"pipeline": "p1",
+		"job":      "deploy",
+		"wait":     false,
+	}, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.Builds) != 1 || state.ID != strconv.Itoa(fake.Builds[0].ID) {
+		t.Fatalf("expected build to be triggered, got %v", fake.Builds)
+	}
+	if state.Attributes["status"] != string(atc.StatusPending) {
+		t.Fatalf("expected pending build, got %v", state.Attributes)
+	}
+
+	// The status of the build is refreshed.
+	fake.SetBuildStatus(fake.Builds[0].ID, atc.StatusSucceeded)
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["status"] != string(atc.StatusSucceeded) {
+		t.Fatalf("expected succeeded build, got %v", state.Attributes)
+	}
+}
@@ -0,0 +1,198 @@
+package concourse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// jobID builds the composite "<team>/<pipeline>/<job>" resource ID of a job.
+func jobID(team, pipeline, job string) string {
+	return fmt.Sprintf("%s/%s/%s", team, pipeline, job)
+}
+
+// parseJobID splits a composite "<team>/<pipeline>/<job>" ID into its parts.
+func parseJobID(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("id \"%s\" must be in the form <team>/<pipeline>/<job>", id)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// setJobBuild stores ID, name, status and timestamps of a job's build using the given attribute prefix.
+// Attributes are reset if the job does not have such a build (yet).
+func setJobBuild(d *schema.ResourceData, prefix string, build *atc.Build) {
+	if build == nil {
+		build = &atc.Build{}
+	}
+	d.Set(prefix+"_id", build.ID)
+	d.Set(prefix+"_name", build.Name)
+	d.Set(prefix+"_status", build.Status)
+	d.Set(prefix+"_start_time", int(build.StartTime))
+	d.Set(prefix+"_end_time", int(build.EndTime))
+}
+
+// jobBuildSchema adds the computed attributes written by setJobBuild to the given schema.
+func jobBuildSchema(s map[string]*schema.Schema, prefix, description string) map[string]*schema.Schema {
+	s[prefix+"_id"] = &schema.Schema{
+		Description: fmt.Sprintf("ID of the %s", description),
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+	s[prefix+"_name"] = &schema.Schema{
+		Description: fmt.Sprintf("Name (build number) of the %s", description),
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	s[prefix+"_status"] = &schema.Schema{
+		Description: fmt.Sprintf("Status of the %s", description),
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	s[prefix+"_start_time"] = &schema.Schema{
+		Description: fmt.Sprintf("Start time of the %s (unix timestamp)", description),
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+	s[prefix+"_end_time"] = &schema.Schema{
+		Description: fmt.Sprintf("End time of the %s (unix timestamp)", description),
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+	return s
+}
+
+func setJobPaused(d *schema.ResourceData, m interface{}) error {
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	name := d.Get("name").(string)
+	paused := d.Get("paused").(bool)
+
+	concourse := m.(Config).Concourse().Team(team)
+
+	fn := concourse.UnpauseJob
+	if paused {
+		fn = concourse.PauseJob
+	}
+	found, err := fn(pipeline, name)
+	if err != nil {
+		return fmt.Errorf("unable to set paused state of job \"%s\" in pipeline \"%s\" of team \"%s\" to %v: %v", name, pipeline, team, paused, err)
+	}
+	if !found {
+		return fmt.Errorf("job \"%s\" not found in pipeline \"%s\" of team \"%s\"", name, pipeline, team)
+	}
+	return nil
+}
+
+func resourceJobCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setJobPaused(d, m); err != nil {
+		return err
+	}
+	d.SetId(jobID(d.Get("team").(string), d.Get("pipeline").(string), d.Get("name").(string)))
+	return resourceJobRead(d, m)
+}
+
+func resourceJobRead(d *schema.ResourceData, m interface{}) error {
+	team, pipeline, name, err := parseJobID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	job, found, err := m.(Config).Concourse().Team(team).Job(pipeline, name)
+	if err != nil {
+		return fmt.Errorf("unable to fetch job \"%s\" of pipeline \"%s\" in team \"%s\": %v", name, pipeline, team, err)
+	}
+
+	// If the job cannot be found, it (or its pipeline) has probably already been deleted.
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("team", team)
+	d.Set("pipeline", pipeline)
+	d.Set("name", job.Name)
+	d.Set("paused", job.Paused)
+	setJobBuild(d, "finished_build", job.FinishedBuild)
+	setJobBuild(d, "next_build", job.NextBuild)
+
+	return nil
+}
+
+func resourceJobUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("paused") {
+		if err := setJobPaused(d, m); err != nil {
+			return err
+		}
+	}
+	return resourceJobRead(d, m)
+}
+
+func resourceJobDelete(d *schema.ResourceData, m interface{}) error {
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	name := d.Get("name").(string)
+
+	// Jobs are owned by their pipeline, so all we can do is to restore the default (unpaused) state.
+	// A job that no longer exists does not need to be unpaused.
+	if _, err := m.(Config).Concourse().Team(team).UnpauseJob(pipeline, name); err != nil {
+		return fmt.Errorf("unable to unpause job \"%s\" of pipeline \"%s\" in team \"%s\": %v", name, pipeline, team, err)
+	}
+	return nil
+}
+
+func resourceJobState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if err := resourceJobRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("no job found for %s", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceJob() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"team": {
+			Description: "Team name",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"pipeline": {
+			Description: "Pipeline name",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "Job name",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"paused": {
+			Description: "Paused",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+	}
+	s = jobBuildSchema(s, "finished_build", "latest finished build")
+	s = jobBuildSchema(s, "next_build", "next (pending or running) build")
+
+	return &schema.Resource{
+		Create: resourceJobCreate,
+		Read:   resourceJobRead,
+		Update: resourceJobUpdate,
+		Delete: resourceJobDelete,
+		Schema: s,
+		Importer: &schema.ResourceImporter{
+			State: resourceJobState,
+		},
+	}
+}
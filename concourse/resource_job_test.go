@@ -0,0 +1,75 @@
+package concourse
+
+import (
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceJob(t *testing.T) {
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	fake.AddPipeline("main", "p1", atc.Config{})
+	fake.AddJob("main", "p1", atc.Job{Name: "deploy"})
+	build := fake.AddBuild("main", "p1", "deploy", atc.StatusSucceeded)
+	fake.Jobs["main/p1"][0].FinishedBuild = &build
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourceJob()
+	raw := map[string]interface{}{
+		"team":     "main",
+		"pipeline": "p1",
+		"name":     "deploy",
+		"paused":   true,
+	}
+
+	state, err := applyResource(t, r, nil, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.ID != "main/p1/deploy" {
+		t.Fatalf("unexpected ID %s", state.ID)
+	}
+	if job, _ := fake.Job("main", "p1", "deploy"); !job.Paused {
+		t.Fatalf("expected job to be paused")
+	}
+	if state.Attributes["finished_build_status"] != "succeeded" || state.Attributes["finished_build_name"] != build.Name {
+		t.Fatalf("expected finished build to be read, got %v", state.Attributes)
+	}
+
+	// Unpausing the job outside of Terraform is detected and reverted.
+	fake.mu.Lock()
+	fake.Jobs["main/p1"][0].Paused = false
+	fake.mu.Unlock()
+
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["paused"] != "false" {
+		t.Fatalf("expected drift to be detected, got %v", state.Attributes)
+	}
+	state, err = applyResource(t, r, state, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job, _ := fake.Job("main", "p1", "deploy"); !job.Paused {
+		t.Fatalf("expected job to be paused again")
+	}
+
+	// Destroying the resource restores the default unpaused state.
+	if _, err := r.Apply(state, &terraform.InstanceDiff{Destroy: true}, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job, _ := fake.Job("main", "p1", "deploy"); job.Paused {
+		t.Fatalf("expected job to be unpaused")
+	}
+
+	// Jobs that do not exist cannot be managed.
+	raw["name"] = "missing"
+	if _, err := applyResource(t, r, nil, raw, cfg); err == nil {
+		t.Fatalf("expected missing job to fail")
+	}
+}
@@ -7,14 +7,25 @@ import (
 	"strings"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"sigs.k8s.io/yaml"
 )
 
-// pipelineIDAsString converts a given numeric team ID, which is required, because Terraform resource data IDs must be
-// strings.
-func pipelineIDAsString(id int) string {
-	return fmt.Sprintf("%d", id)
+// pipelineID builds the composite "<team>/<pipeline-name>" resource ID of a pipeline. Pipeline names are only
+// unique within a team, which is why the team name is part of the ID.
+func pipelineID(team, name string) string {
+	return fmt.Sprintf("%s/%s", team, name)
+}
+
+// parsePipelineID splits a composite "<team>/<pipeline-name-or-id>" ID into its team and pipeline parts.
+func parsePipelineID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("id \"%s\" must be in the form <team>/<pipeline-name-or-id>", id)
+	}
+	return parts[0], parts[1], nil
 }
 
 func resourcePipelineCreate(d *schema.ResourceData, m interface{}) error {
@@ -32,19 +43,46 @@ func resourcePipelineCreate(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return fmt.Errorf("could not fetch details of pipeline \"%s\" prior to creation: %v", name, err)
 	}
+
+	writeConfig := true
+	configVersion := "1"
 	if exists {
-		return fmt.Errorf("pipeline \"%s\" does already exist in team \"%s\"", name, team)
+		adopt := d.Get("adopt_existing").(string)
+		if adopt == "fail" {
+			return fmt.Errorf("pipeline \"%s\" does already exist in team \"%s\" (set adopt_existing to adopt it)", name, team)
+		}
+
+		var newConfig atc.Config
+		if err := atc.UnmarshalConfig([]byte(config), &newConfig); err != nil {
+			return fmt.Errorf("unable to parse config of pipeline \"%s\": %v", name, err)
+		}
+
+		existingConfig, existingConfigVersion, found, err := concourse.PipelineConfig(name)
+		if err != nil || !found {
+			return fmt.Errorf("unable to read configuration of existing pipeline \"%s\" in team \"%s\": %v", name, team, err)
+		}
+		configVersion = existingConfigVersion
+		writeConfig = existingConfig.Diff(&bytes.Buffer{}, newConfig)
+
+		if adopt == "identical" && (writeConfig || pipeline.Paused != paused || pipeline.Public != public) {
+			return fmt.Errorf("pipeline \"%s\" does already exist in team \"%s\" with a different config, paused or public state (set adopt_existing to \"overwrite\" to adopt it anyway)", name, team)
+		}
 	}
 
-	created, updated, _, err := concourse.CreateOrUpdatePipelineConfig(name, "1", []byte(config), false) // todo: see issue #3
-	if err != nil {
-		return fmt.Errorf("could not create pipeline config: %v", err)
+	if writeConfig {
+		created, updated, _, err := concourse.CreateOrUpdatePipelineConfig(name, configVersion, []byte(config), false) // todo: see issue #3
+		if err != nil {
+			return fmt.Errorf("could not create pipeline config: %v", err)
+		}
+		if !created && !updated {
+			return fmt.Errorf("pipeline \"%s\" does not exist in team \"%s\" after an attempt to create it", name, team)
+		}
 	}
 
 	// Now we check, if the pipeline has been created...
 	pipeline, exists, err = concourse.Pipeline(name)
-	if !created && !updated {
-		return fmt.Errorf("pipeline \"%s\" does not exist in team \"%s\" after an attempt to create it", name, team)
+	if err != nil || !exists {
+		return fmt.Errorf("unable to read pipeline \"%s\" in team \"%s\" after attempting to create it: %v", name, team, err)
 	}
 
 	// We check if the configuration has been created.
@@ -53,10 +91,13 @@ func resourcePipelineCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("unable to read pipeline config for pipeline \"%s\" in team \"%s\" after attempting to create it: %v", name, team, err)
 	}
 
-	d.Set("config", config)
+	if err := setPipelineConfigState(d, config); err != nil {
+		return err
+	}
 	d.Set("config_version", configVersion)
+	d.Set("pipeline_id", pipeline.ID)
 
-	d.SetId(pipelineIDAsString(pipeline.ID))
+	d.SetId(pipelineID(team, name))
 
 	if pipeline.Paused != paused {
 		var fn func(name string) (bool, error)
@@ -86,10 +127,20 @@ func resourcePipelineCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourcePipelineRead(d *schema.ResourceData, m interface{}) error {
-	id := d.Id()
 	team := d.Get("team").(string)
 	name := d.Get("name").(string)
 
+	// Team and name are not known yet if the resource is being imported, so we fall back to the ID.
+	importing := team == "" || name == ""
+	if importing {
+		var err error
+		if team, name, err = parsePipelineID(d.Id()); err != nil {
+			return err
+		}
+	}
+
+	knownID := d.Get("pipeline_id").(int)
+
 	concourse := m.(Config).Concourse().Team(team)
 
 	pipelines, err := concourse.ListPipelines()
@@ -97,49 +148,75 @@ func resourcePipelineRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("unable to list pipelines of team \"%s\": %v", team, err)
 	}
 
-	for _, pipeline := range pipelines {
-		strID := pipelineIDAsString(pipeline.ID)
-
-		// To simplify things, we allow either the (internal) resource ID
-		// or the name to be used when importing a team resource.
-		if id == strID || id == pipeline.Name || (name != "" && name == pipeline.Name) {
-			d.SetId(strID)
-			if err := d.Set("name", pipeline.Name); err != nil {
-				return err
-			}
-			d.Set("team", pipeline.TeamName)
-			d.Set("paused", pipeline.Paused)
-			d.Set("public", pipeline.Public)
-			lastConfigStr := d.Get("config").(string)
-
-			var lastConfig atc.Config
-			if err := atc.UnmarshalConfig([]byte(lastConfigStr), &lastConfig); err != nil {
-				return fmt.Errorf("error parsing last known config: %v\n\n%s", err, lastConfigStr)
-			}
+	// Matching the last known numeric ID lets us notice pipelines renamed outside of Terraform, so it takes
+	// precedence over the name. To simplify things, we allow either the numeric pipeline ID or the name to be used
+	// when importing a pipeline resource.
+	match := -1
+	for i, pipeline := range pipelines {
+		if knownID != 0 && knownID == pipeline.ID {
+			match = i
+			break
+		}
+		if match < 0 && (name == pipeline.Name || (importing && name == strconv.Itoa(pipeline.ID))) {
+			match = i
+		}
+	}
 
-			currentConfig, version, _, err := concourse.PipelineConfig(pipeline.Name)
-			if err != nil {
-				return fmt.Errorf("unable to read configuration of pipeline \"%s\": %v", pipeline.Name, err)
-			}
+	// If a pipeline with the given ID/name cannot be found, it has probably been already been deleted.
+	// We will have to update the state then...
+	if match < 0 {
+		d.SetId("")
+		return nil
+	}
 
-			if lastConfig.Diff(&bytes.Buffer{}, currentConfig) {
-				configBytes, err := yaml.Marshal(currentConfig)
-				if err != nil {
-					return fmt.Errorf("unable to marshal config: %v", err)
-				}
-				d.Set("config", string(configBytes))
-				d.Set("config_version", version)
-			}
+	pipeline := pipelines[match]
+	d.SetId(pipelineID(team, pipeline.Name))
+	if err := d.Set("name", pipeline.Name); err != nil {
+		return err
+	}
+	d.Set("team", pipeline.TeamName)
+	d.Set("paused", pipeline.Paused)
+	d.Set("public", pipeline.Public)
+	d.Set("pipeline_id", pipeline.ID)
+
+	currentConfig, version, _, err := concourse.PipelineConfig(pipeline.Name)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration of pipeline \"%s\": %v", pipeline.Name, err)
+	}
+	d.Set("config_version", version)
 
-			return nil
+	d.Set("config_changes", []interface{}{})
+
+	if d.Get("store_config").(string) == "hash" {
+		hash, err := pipelineConfigHash(currentConfig)
+		if err != nil {
+			return err
 		}
+		d.Set("config", hash)
+		return nil
 	}
 
-	// If a pipeline with the given ID/name cannot be found, it has probably been already been deleted.
-	// We will have to update the state then...
-	d.SetId("")
-	return nil
+	lastConfigStr := d.Get("config").(string)
+
+	// The config may have been stored as hash before "store_config" has been changed.
+	if strings.HasPrefix(lastConfigStr, pipelineConfigHashPrefix) {
+		lastConfigStr = ""
+	}
+
+	var lastConfig atc.Config
+	if err := atc.UnmarshalConfig([]byte(lastConfigStr), &lastConfig); err != nil {
+		return fmt.Errorf("error parsing last known config: %v\n\n%s", err, lastConfigStr)
+	}
+
+	if lastConfigStr == "" || lastConfig.Diff(&bytes.Buffer{}, currentConfig) {
+		configBytes, err := yaml.Marshal(currentConfig)
+		if err != nil {
+			return fmt.Errorf("unable to marshal config: %v", err)
+		}
+		d.Set("config", string(configBytes))
+	}
 
+	return nil
 }
 
 func resourcePipelineUpdate(d *schema.ResourceData, m interface{}) error {
@@ -219,10 +296,12 @@ func resourcePipelineUpdate(d *schema.ResourceData, m interface{}) error {
 				}
 				return fmt.Errorf("unable to update configuration of pipeline \"%s\" of team \"%s\" (current version: %d): %v, %s", name, team, version, err, strings.Join(warningsStr, ", "))
 			}
-			d.Set("config_version", version+1)
+			d.Set("config_version", strconv.Itoa(version+1))
 		}
 	}
 
+	d.SetId(pipelineID(team, name))
+
 	return resourcePipelineRead(d, m)
 }
 
@@ -251,8 +330,11 @@ func resourcePipelineExists(d *schema.ResourceData, m interface{}) (bool, error)
 
 		return false, fmt.Errorf("unable to list pipelines: %v", err)
 	}
+	// Pipelines renamed outside of Terraform are still found by their last known ID, so that Read can update the
+	// name instead of planning a new pipeline.
+	knownID := d.Get("pipeline_id").(int)
 	for _, pipeline := range pipelines {
-		if pipeline.Name == name && pipeline.TeamName == team {
+		if pipeline.TeamName == team && (pipeline.Name == name || (knownID != 0 && pipeline.ID == knownID)) {
 			return true, nil
 		}
 	}
@@ -263,36 +345,15 @@ func resourcePipelineExists(d *schema.ResourceData, m interface{}) (bool, error)
 
 func resourcePipelineState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	teamSlashName := d.Id()
-	name := strings.Split(teamSlashName, "/")
-	if len(name) != 2 {
-		return nil, fmt.Errorf("id must be in the form <team>/<pipeline-name-or-id>")
+	if _, _, err := parsePipelineID(teamSlashName); err != nil {
+		return nil, err
 	}
-
-	config, versionStr, found, err := m.(Config).Concourse().Team(name[0]).PipelineConfig(name[1])
-	if err != nil {
-		return nil, fmt.Errorf("error retrieving pipeline %s: %v", teamSlashName, err)
+	if err := resourcePipelineRead(d, m); err != nil {
+		return nil, err
 	}
-	if !found {
+	if d.Id() == "" {
 		return nil, fmt.Errorf("no pipeline found for %s", teamSlashName)
 	}
-
-	d.SetId(name[1])
-	d.Set("team", name[0])
-	d.Set("name", name[1])
-
-	version, err := strconv.Atoi(versionStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid config version: %v", err)
-	}
-	d.Set("config_version", version)
-
-	configBytes, err := yaml.Marshal(&config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal config: %v", err)
-	}
-	configStr := string(configBytes)
-	d.Set("config", configStr)
-
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -303,6 +364,21 @@ func resourcePipeline() *schema.Resource {
 		Update: resourcePipelineUpdate,
 		Delete: resourcePipelineDelete,
 		Exists: resourcePipelineExists,
+
+		CustomizeDiff: customdiff.Sequence(
+			resourcePipelineCustomizeDiff,
+			resourcePipelineConfigChangesDiff,
+		),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourcePipelineV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourcePipelineStateUpgradeV0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"team": {
 				Description: "Team name",
@@ -328,15 +404,48 @@ func resourcePipeline() *schema.Resource {
 				Default:     false,
 			},
 			"config": {
-				Description: "Pipeline configuration YAML",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "Pipeline configuration YAML",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: pipelineConfigDiffSuppress,
+			},
+			"store_config": {
+				Description:  "How the config is stored in the state: full or hash (only a SHA-256 of the normalized config)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "full",
+				ValidateFunc: validation.StringInSlice([]string{"full", "hash"}, false),
+			},
+			"config_changes": {
+				Description: "Names of the parts of the config that change, only planned if store_config is hash",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"config_version": {
 				Description: "Pipeline configuration version",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"pipeline_id": {
+				Description: "Numeric pipeline ID",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"adopt_existing": {
+				Description:  "What to do if the pipeline already exists on creation: fail, overwrite or identical (adopt only if nothing would change)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "fail",
+				ValidateFunc: validation.StringInSlice([]string{"fail", "overwrite", "identical"}, false),
+			},
+			"worker_check": {
+				Description:  "Check at plan time that workers can satisfy the tags and platforms of all steps: off, warn (Terraform log only) or fail",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "off",
+				ValidateFunc: validation.StringInSlice([]string{"off", "warn", "fail"}, false),
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: resourcePipelineState,
@@ -0,0 +1,163 @@
+package concourse
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// pipelineConfigHashPrefix marks pipeline configs in the state that have been replaced by their hash.
+const pipelineConfigHashPrefix = "sha256:"
+
+// pipelineConfigHash returns the SHA-256 of a pipeline config. The config is normalized by encoding the parsed
+// config as JSON, so that formatting, comments and the order of keys do not change the hash.
+func pipelineConfigHash(config atc.Config) (string, error) {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal config: %v", err)
+	}
+	return fmt.Sprintf("%s%x", pipelineConfigHashPrefix, sha256.Sum256(b)), nil
+}
+
+// pipelineConfigStringHash parses a pipeline config YAML and returns its hash. Values that already are a hash are
+// returned as they are.
+func pipelineConfigStringHash(configStr string) (string, error) {
+	if strings.HasPrefix(configStr, pipelineConfigHashPrefix) {
+		return configStr, nil
+	}
+	var config atc.Config
+	if err := atc.UnmarshalConfig([]byte(configStr), &config); err != nil {
+		return "", fmt.Errorf("unable to parse config: %v", err)
+	}
+	return pipelineConfigHash(config)
+}
+
+// setPipelineConfigState stores the given config YAML, or only its hash if "store_config" is set to "hash", in
+// the state.
+func setPipelineConfigState(d *schema.ResourceData, configStr string) error {
+	if d.Get("store_config").(string) == "hash" {
+		hash, err := pipelineConfigStringHash(configStr)
+		if err != nil {
+			return err
+		}
+		configStr = hash
+	}
+	return d.Set("config", configStr)
+}
+
+// pipelineConfigDiffSuppress suppresses the diff between the hash of the config in the state and the configured
+// config YAML if "store_config" is set to "hash" and the hashes match.
+func pipelineConfigDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if d.Get("store_config").(string) != "hash" || !strings.HasPrefix(old, pipelineConfigHashPrefix) {
+		return false
+	}
+	hash, err := pipelineConfigStringHash(new)
+	return err == nil && hash == old
+}
+
+// pipelineConfigKinds lists the kinds of named parts of a pipeline config in the order they are summarized.
+var pipelineConfigKinds = []string{"group", "var source", "resource", "resource type", "job"}
+
+// pipelineConfigParts returns the named parts of a pipeline config by kind and name.
+func pipelineConfigParts(config atc.Config) map[string]map[string]interface{} {
+	parts := map[string]map[string]interface{}{}
+	for _, kind := range pipelineConfigKinds {
+		parts[kind] = map[string]interface{}{}
+	}
+	for _, v := range config.Groups {
+		parts["group"][v.Name] = v
+	}
+	for _, v := range config.VarSources {
+		parts["var source"][v.Name] = v
+	}
+	for _, v := range config.Resources {
+		parts["resource"][v.Name] = v
+	}
+	for _, v := range config.ResourceTypes {
+		parts["resource type"][v.Name] = v
+	}
+	for _, v := range config.Jobs {
+		parts["job"][v.Name] = v
+	}
+	return parts
+}
+
+// pipelineConfigChanges summarizes the differences between two pipeline configs by listing the names of the
+// groups, var sources, resources, resource types and jobs that have been added, removed or changed. Neither
+// old nor new values are part of the summary, as they may contain secrets.
+func pipelineConfigChanges(old, new atc.Config) ([]string, error) {
+	oldParts, newParts := pipelineConfigParts(old), pipelineConfigParts(new)
+
+	changes := []string{}
+	for _, kind := range pipelineConfigKinds {
+		names := make([]string, 0, len(oldParts[kind])+len(newParts[kind]))
+		for name := range oldParts[kind] {
+			names = append(names, name)
+		}
+		for name := range newParts[kind] {
+			if _, ok := oldParts[kind][name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			oldValue, inOld := oldParts[kind][name]
+			newValue, inNew := newParts[kind][name]
+			switch {
+			case !inOld:
+				changes = append(changes, fmt.Sprintf("%s \"%s\" added", kind, name))
+			case !inNew:
+				changes = append(changes, fmt.Sprintf("%s \"%s\" removed", kind, name))
+			default:
+				oldJSON, err := json.Marshal(oldValue)
+				if err != nil {
+					return nil, err
+				}
+				newJSON, err := json.Marshal(newValue)
+				if err != nil {
+					return nil, err
+				}
+				if string(oldJSON) != string(newJSON) {
+					changes = append(changes, fmt.Sprintf("%s \"%s\" changed", kind, name))
+				}
+			}
+		}
+	}
+	return changes, nil
+}
+
+// resourcePipelineConfigChangesDiff plans a summary of the config changes if only the hash of the config is
+// stored, since the plan cannot show the previous config then.
+func resourcePipelineConfigChangesDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || d.Get("store_config").(string) != "hash" || !d.HasChange("config") || !d.NewValueKnown("config") {
+		return nil
+	}
+
+	oldName, _ := d.GetChange("name")
+	oldTeam, _ := d.GetChange("team")
+
+	oldConfig, _, found, err := m.(Config).Concourse().Team(oldTeam.(string)).PipelineConfig(oldName.(string))
+	if err != nil {
+		return fmt.Errorf("unable to read configuration of pipeline \"%s\": %v", oldName.(string), err)
+	}
+	if !found {
+		return nil
+	}
+
+	var newConfig atc.Config
+	if err := atc.UnmarshalConfig([]byte(d.Get("config").(string)), &newConfig); err != nil {
+		return fmt.Errorf("unable to parse config of pipeline \"%s\": %v", d.Get("name").(string), err)
+	}
+
+	changes, err := pipelineConfigChanges(oldConfig, newConfig)
+	if err != nil {
+		return fmt.Errorf("unable to compare configs of pipeline \"%s\": %v", d.Get("name").(string), err)
+	}
+	return d.SetNew("config_changes", changes)
+}
@@ -0,0 +1,63 @@
+package concourse
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourcePipelineV0 describes the schema of the concourse_pipeline resource prior to the introduction of
+// composite "<team>/<pipeline-name>" IDs.
+func resourcePipelineV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"public": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"config": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"config_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourcePipelineStateUpgradeV0 migrates states that either used the numeric pipeline ID or the bare pipeline
+// name as resource ID to the composite "<team>/<pipeline-name>" format.
+func resourcePipelineStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	team, _ := rawState["team"].(string)
+	name, _ := rawState["name"].(string)
+	if team == "" || name == "" {
+		return nil, fmt.Errorf("unable to upgrade pipeline state: team and name must be set")
+	}
+	rawState["id"] = pipelineID(team, name)
+
+	// Numbers are decoded as float64, which would be formatted with an exponent if they are large.
+	switch version := rawState["config_version"].(type) {
+	case float64:
+		rawState["config_version"] = strconv.FormatInt(int64(version), 10)
+	}
+
+	return rawState, nil
+}
@@ -0,0 +1,86 @@
+package concourse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourcePipelineStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		name     string
+		rawState map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "numeric id",
+			rawState: map[string]interface{}{
+				"id":             "42",
+				"team":           "main",
+				"name":           "batman",
+				"config_version": "3",
+			},
+			expected: map[string]interface{}{
+				"id":             "main/batman",
+				"team":           "main",
+				"name":           "batman",
+				"config_version": "3",
+			},
+		},
+		{
+			name: "name id and numeric config version",
+			rawState: map[string]interface{}{
+				"id":             "batman",
+				"team":           "main",
+				"name":           "batman",
+				"config_version": float64(7),
+			},
+			expected: map[string]interface{}{
+				"id":             "main/batman",
+				"team":           "main",
+				"name":           "batman",
+				"config_version": "7",
+			},
+		},
+		{
+			name: "large numeric config version",
+			rawState: map[string]interface{}{
+				"id":             "batman",
+				"team":           "main",
+				"name":           "batman",
+				"config_version": float64(1234567),
+			},
+			expected: map[string]interface{}{
+				"id":             "main/batman",
+				"team":           "main",
+				"name":           "batman",
+				"config_version": "1234567",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := resourcePipelineStateUpgradeV0(c.rawState, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestParsePipelineID(t *testing.T) {
+	team, name, err := parsePipelineID("main/batman")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if team != "main" || name != "batman" {
+		t.Fatalf("expected main/batman, got %s/%s", team, name)
+	}
+
+	for _, id := range []string{"batman", "/batman", "main/", ""} {
+		if _, _, err := parsePipelineID(id); err == nil {
+			t.Fatalf("expected an error for id \"%s\"", id)
+		}
+	}
+}
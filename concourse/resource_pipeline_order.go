@@ -0,0 +1,130 @@
+package concourse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourcePipelineOrderApply(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Get("team").(string)
+	team := m.(Config).Concourse().Team(teamName)
+
+	names := expandStringList(d.Get("pipelines").([]interface{}))
+
+	pipelines, err := team.ListPipelines()
+	if err != nil {
+		return fmt.Errorf("unable to list pipelines of team \"%s\": %v", teamName, err)
+	}
+
+	var missing []string
+	for _, name := range names {
+		found := false
+		for _, pipeline := range pipelines {
+			found = found || pipeline.Name == name
+		}
+		if !found {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("unable to order pipelines of team \"%s\", pipelines not found: %s", teamName, strings.Join(missing, ", "))
+	}
+
+	// Concourse only orders the pipelines it is given, so all other pipelines are appended in their current order
+	// to move the ordered ones to the top.
+	ordering := append([]string{}, names...)
+	for _, pipeline := range pipelines {
+		if !containsString(names, pipeline.Name) {
+			ordering = append(ordering, pipeline.Name)
+		}
+	}
+
+	if err := team.OrderingPipelines(ordering); err != nil {
+		return fmt.Errorf("unable to order pipelines of team \"%s\": %v", teamName, err)
+	}
+
+	d.SetId(teamName)
+	return resourcePipelineOrderRead(d, m)
+}
+
+func resourcePipelineOrderRead(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Id()
+	client := m.(Config).Concourse()
+
+	if exists, err := teamExists(client, teamName); err != nil {
+		return err
+	} else if !exists {
+		d.SetId("")
+		return nil
+	}
+
+	pipelines, err := client.Team(teamName).ListPipelines()
+	if err != nil {
+		return fmt.Errorf("unable to list pipelines of team \"%s\": %v", teamName, err)
+	}
+
+	// Pipelines are listed in dashboard order. Only as many pipelines as have been configured are tracked, so that
+	// new pipelines shown after them do not cause a diff, while any other pipeline on top of them, e.g. because a
+	// configured one has been deleted, does. While importing, the order of all pipelines is tracked.
+	configured := d.Get("pipelines").([]interface{})
+	names := make([]interface{}, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		if len(configured) > 0 && len(names) == len(configured) {
+			break
+		}
+		names = append(names, pipeline.Name)
+	}
+
+	d.Set("team", teamName)
+
+	if err := d.Set("pipelines", names); err != nil {
+		return fmt.Errorf("unable to set pipelines field: %v", err)
+	}
+
+	return nil
+}
+
+func resourcePipelineOrderDelete(d *schema.ResourceData, m interface{}) error {
+	// There is no default order to go back to, so we only drop the resource from the state.
+	return nil
+}
+
+func resourcePipelineOrderState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	teamName := d.Id()
+	if err := resourcePipelineOrderRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("no team found for %s", teamName)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourcePipelineOrder() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelineOrderApply,
+		Read:   resourcePipelineOrderRead,
+		Update: resourcePipelineOrderApply,
+		Delete: resourcePipelineOrderDelete,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pipelines": {
+				Description: "Names of the pipelines in the order they are shown on the dashboard, other pipelines are shown after them",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourcePipelineOrderState,
+		},
+	}
+}
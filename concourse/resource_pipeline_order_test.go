@@ -0,0 +1,99 @@
+package concourse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourcePipelineOrder(t *testing.T) {
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		fake.AddPipeline("main", name, atc.Config{})
+	}
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourcePipelineOrder()
+	raw := map[string]interface{}{
+		"team":      "main",
+		"pipelines": []interface{}{"c", "a"},
+	}
+
+	state, err := applyResource(t, r, nil, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := fake.PipelineNames("main"); !reflect.DeepEqual(names, []string{"c", "a", "b", "d"}) {
+		t.Fatalf("expected ordered pipelines first, got %v", names)
+	}
+
+	// New pipelines do not cause a diff.
+	fake.AddPipeline("main", "e", atc.Config{})
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), cfg); err != nil || !diff.Empty() {
+		t.Fatalf("expected no diff after a pipeline has been added, got %v (%v)", diff, err)
+	}
+
+	// Reordering the pipelines in the UI is detected and reverted.
+	fake.mu.Lock()
+	p := fake.Pipelines["main"]
+	p[0], p[1] = p[1], p[0]
+	fake.mu.Unlock()
+
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["pipelines.0"] != "a" {
+		t.Fatalf("expected drift to be detected, got %v", state.Attributes)
+	}
+	if _, err := applyResource(t, r, state, raw, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := fake.PipelineNames("main"); !reflect.DeepEqual(names[:2], []string{"c", "a"}) {
+		t.Fatalf("expected order to be restored, got %v", names)
+	}
+
+	// Other pipelines that have been moved above the configured ones are detected as well.
+	fake.mu.Lock()
+	p = fake.Pipelines["main"]
+	p[0], p[2] = p[2], p[0]
+	fake.mu.Unlock()
+
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["pipelines.0"] != "b" {
+		t.Fatalf("expected drift to be detected, got %v", state.Attributes)
+	}
+
+	// Ordered pipelines that have been deleted outside of Terraform show up as drift, and the order cannot be
+	// applied until they are removed from the configuration.
+	fake.mu.Lock()
+	i := fake.pipelineIndex("main", "a")
+	fake.Pipelines["main"] = append(p[:i], p[i+1:]...)
+	fake.mu.Unlock()
+
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["pipelines.0"] != "b" || state.Attributes["pipelines.1"] != "c" {
+		t.Fatalf("expected drift to be detected, got %v", state.Attributes)
+	}
+	if _, err := applyResource(t, r, state, raw, cfg); err == nil || !strings.Contains(err.Error(), "not found: a") {
+		t.Fatalf("expected missing pipeline to be reported, got %v", err)
+	}
+	if names := fake.PipelineNames("main"); !reflect.DeepEqual(names[:2], []string{"b", "c"}) {
+		t.Fatalf("expected order to be kept, got %v", names)
+	}
+}
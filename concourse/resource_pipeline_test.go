@@ -0,0 +1,231 @@
+package concourse
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourcePipelineAdoptExisting(t *testing.T) {
+	existing := "jobs:\n- name: hello\n  plan:\n  - task: hello\n    config:\n      platform: linux\n      run: {path: echo}\n"
+	changed := "jobs:\n- name: goodbye\n  plan:\n  - task: goodbye\n    config:\n      platform: linux\n      run: {path: echo}\n"
+
+	cases := []struct {
+		name        string
+		adopt       string
+		config      string
+		paused      bool
+		expectError bool
+		expectedJob string
+	}{
+		{name: "fail", adopt: "fail", config: existing, expectError: true, expectedJob: "hello"},
+		{name: "identical", adopt: "identical", config: existing, expectedJob: "hello"},
+		{name: "different config", adopt: "identical", config: changed, expectError: true, expectedJob: "hello"},
+		{name: "different paused state", adopt: "identical", config: existing, paused: true, expectError: true, expectedJob: "hello"},
+		{name: "overwrite", adopt: "overwrite", config: changed, paused: true, expectedJob: "goodbye"},
+	}
+
+	for _, c := range cases {
+		var config atc.Config
+		if err := atc.UnmarshalConfig([]byte(existing), &config); err != nil {
+			t.Fatalf("unable to parse config: %v", err)
+		}
+
+		fake := newFakeATC()
+		fake.AddTeam("main", nil)
+		fake.AddPipeline("main", "hello", config)
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		state, err := applyResource(t, resourcePipeline(), nil, map[string]interface{}{
+			"team":           "main",
+			"name":           "hello",
+			"config":         c.config,
+			"paused":         c.paused,
+			"adopt_existing": c.adopt,
+		}, cfg)
+		if c.expectError != (err != nil) {
+			t.Fatalf("%s: expected error %v, got %v", c.name, c.expectError, err)
+		}
+		if !c.expectError && state.ID != "main/hello" {
+			t.Fatalf("%s: expected pipeline to be adopted, got ID %s", c.name, state.ID)
+		}
+
+		if job := fake.Configs["main/hello"].Jobs[0].Name; job != c.expectedJob {
+			t.Fatalf("%s: expected job %s in pipeline config, got %s", c.name, c.expectedJob, job)
+		}
+		if pipeline, _ := fake.Pipeline("main", "hello"); !c.expectError && pipeline.Paused != c.paused {
+			t.Fatalf("%s: expected paused state %v, got %v", c.name, c.paused, pipeline.Paused)
+		}
+	}
+}
+
+func TestResourcePipelineStoreConfigHash(t *testing.T) {
+	config := "jobs:\n- name: hello\n  plan:\n  - task: hello\n    config:\n      platform: linux\n      run: {path: echo, args: [((secret))]}\n"
+	reformatted := "jobs:\n  - name: hello\n    plan:\n      - task: hello\n        config: {run: {args: [((secret))], path: echo}, platform: linux}\n"
+	changed := "jobs:\n- name: hello\n  plan:\n  - task: hello\n    config:\n      platform: linux\n      run: {path: echo, args: [bye]}\n- name: goodbye\n  plan: []\n"
+
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourcePipeline()
+	raw := map[string]interface{}{
+		"team":         "main",
+		"name":         "hello",
+		"config":       config,
+		"store_config": "hash",
+	}
+
+	state, err := applyResource(t, r, nil, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hash := state.Attributes["config"]
+	if !strings.HasPrefix(hash, pipelineConfigHashPrefix) {
+		t.Fatalf("expected only the hash of the config to be stored, got %s", hash)
+	}
+
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["config"] != hash {
+		t.Fatalf("expected hash of the server's config to match, got %s instead of %s", state.Attributes["config"], hash)
+	}
+
+	raw["config"] = reformatted
+	if diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), cfg); err != nil || !diff.Empty() {
+		t.Fatalf("expected no diff for an equivalent config, got %v (%v)", diff, err)
+	}
+
+	raw["config"] = changed
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.Attributes["config_changes.#"] == nil || diff.Attributes["config_changes.#"].New != "2" ||
+		diff.Attributes["config_changes.0"].New != "job \"goodbye\" added" ||
+		diff.Attributes["config_changes.1"].New != "job \"hello\" changed" {
+		t.Fatalf("expected summary of config changes, got %v", diff.Attributes)
+	}
+
+	state, err = r.Apply(state, diff, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.Configs["main/hello"].Jobs) != 2 {
+		t.Fatalf("expected config to be updated, got %v", fake.Configs["main/hello"])
+	}
+	if strings.Contains(state.String(), "bye") {
+		t.Fatalf("expected config to be kept out of the state, got %s", state.String())
+	}
+}
+
+func TestPipelineConfigHashServerRoundTrip(t *testing.T) {
+	configs := []string{
+		"jobs:\n- name: hello\n  plan:\n  - task: hello\n    config:\n      platform: linux\n      run: {path: echo}\n",
+		"resources:\n- name: repo\n  type: git\n  check_every: 1h\n  source: {uri: ((uri)), branch: master}\n" +
+			"resource_types:\n- name: custom\n  type: registry-image\n  source: {repository: custom}\n  privileged: true\n" +
+			"groups:\n- name: all\n  jobs: [build]\n" +
+			"jobs:\n- name: build\n  serial: true\n  serial_groups: [deploy]\n  max_in_flight: 1\n  plan:\n" +
+			"  - in_parallel:\n    - get: repo\n      trigger: true\n      params: {depth: 1}\n" +
+			"  - task: build\n    file: repo/ci/build.yml\n    vars: {key: value, list: [1, 2]}\n" +
+			"  - put: repo\n    params: {repository: repo}\n  on_failure:\n    task: notify\n    file: repo/ci/notify.yml\n",
+	}
+
+	for i, configStr := range configs {
+		hash, err := pipelineConfigStringHash(configStr)
+		if err != nil {
+			t.Fatalf("config %d: unexpected error: %v", i, err)
+		}
+
+		// The ATC stores the config as JSON and serves it as JSON again, which is what the hash of the pipeline
+		// config on the server is computed from.
+		var config atc.Config
+		if err := atc.UnmarshalConfig([]byte(configStr), &config); err != nil {
+			t.Fatalf("config %d: unable to parse config: %v", i, err)
+		}
+		for j := 0; j < 2; j++ {
+			b, err := json.Marshal(config)
+			if err != nil {
+				t.Fatalf("config %d: unable to marshal config: %v", i, err)
+			}
+			config = atc.Config{}
+			if err := json.Unmarshal(b, &config); err != nil {
+				t.Fatalf("config %d: unable to unmarshal config: %v", i, err)
+			}
+		}
+
+		serverHash, err := pipelineConfigHash(config)
+		if err != nil {
+			t.Fatalf("config %d: unexpected error: %v", i, err)
+		}
+		if serverHash != hash {
+			t.Fatalf("config %d: expected hash of the server's config %s to match %s", i, serverHash, hash)
+		}
+	}
+}
+
+func TestResourcePipelineRenamedOutside(t *testing.T) {
+	config := "jobs:\n- name: hello\n  plan: []\n"
+
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourcePipeline()
+	raw := map[string]interface{}{
+		"team":   "main",
+		"name":   "hello",
+		"config": config,
+	}
+
+	state, err := applyResource(t, r, nil, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created, _ := fake.Pipeline("main", "hello")
+
+	// A pipeline whose name is the numeric ID of another pipeline is only matched by that ID while importing.
+	numeric := &terraform.InstanceState{
+		ID:         "main/" + strconv.Itoa(created.ID),
+		Attributes: map[string]string{"team": "main", "name": strconv.Itoa(created.ID)},
+	}
+	if refreshed, err := r.Refresh(numeric, cfg); err != nil || (refreshed != nil && refreshed.ID != "") {
+		t.Fatalf("expected pipeline to be gone, got %v (%v)", refreshed, err)
+	}
+
+	fake.mu.Lock()
+	fake.Pipelines["main"][0].Name = "renamed"
+	fake.Configs["main/renamed"], fake.Versions["main/renamed"] = fake.Configs["main/hello"], fake.Versions["main/hello"]
+	fake.mu.Unlock()
+
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state == nil || state.ID != "main/renamed" || state.Attributes["name"] != "renamed" {
+		t.Fatalf("expected renamed pipeline to be found by its ID, got %v", state)
+	}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("expected pipeline to be renamed in place, got %v", diff)
+	}
+	if _, err := r.Apply(state, diff, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipeline, ok := fake.Pipeline("main", "hello"); !ok || pipeline.ID != created.ID {
+		t.Fatalf("expected pipeline %d to be renamed back, got %v", created.ID, fake.PipelineNames("main"))
+	}
+}
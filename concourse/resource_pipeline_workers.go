@@ -0,0 +1,161 @@
+package concourse
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// stepRequirement describes the worker a single step of a pipeline needs to run on.
+type stepRequirement struct {
+	Location string
+	Tags     []string
+	Platform string
+}
+
+// pipelineStepRequirements collects the worker requirements of all steps (including hooks and nested steps) and
+// resource checks of a pipeline. Platforms are only known for tasks with an inline config.
+func pipelineStepRequirements(config atc.Config) []stepRequirement {
+	var requirements []stepRequirement
+
+	for _, resource := range config.Resources {
+		requirements = append(requirements, stepRequirement{
+			Location: fmt.Sprintf("resource \"%s\"", resource.Name),
+			Tags:     resource.Tags,
+		})
+	}
+
+	for _, resourceType := range config.ResourceTypes {
+		requirements = append(requirements, stepRequirement{
+			Location: fmt.Sprintf("resource type \"%s\"", resourceType.Name),
+			Tags:     resourceType.Tags,
+		})
+	}
+
+	for _, job := range config.Jobs {
+		location := fmt.Sprintf("job \"%s\"", job.Name)
+		var walk func(step *atc.PlanConfig)
+		walk = func(step *atc.PlanConfig) {
+			if step == nil {
+				return
+			}
+			if step.Get != "" || step.Put != "" || step.Task != "" {
+				requirement := stepRequirement{
+					Location: fmt.Sprintf("%s, step \"%s\"", location, step.Name()),
+					Tags:     step.Tags,
+				}
+				if step.Task != "" && step.TaskConfig != nil {
+					requirement.Platform = step.TaskConfig.Platform
+				}
+				requirements = append(requirements, requirement)
+			}
+			for _, nested := range []*atc.PlanSequence{step.Do, step.Aggregate} {
+				if nested != nil {
+					for i := range *nested {
+						walk(&(*nested)[i])
+					}
+				}
+			}
+			if step.InParallel != nil {
+				for i := range step.InParallel.Steps {
+					walk(&step.InParallel.Steps[i])
+				}
+			}
+			for _, hook := range []*atc.PlanConfig{step.Try, step.Abort, step.Error, step.Failure, step.Ensure, step.Success} {
+				walk(hook)
+			}
+		}
+		for i := range job.Plan {
+			walk(&job.Plan[i])
+		}
+		for _, hook := range []*atc.PlanConfig{job.Abort, job.Error, job.Failure, job.Ensure, job.Success} {
+			walk(hook)
+		}
+	}
+
+	return requirements
+}
+
+// canRunStep reports whether the given worker is able to run a step with the given requirement on behalf of
+// the given team. Untagged steps only run on untagged workers and tagged steps only on workers with all tags.
+func canRunStep(worker atc.Worker, team string, requirement stepRequirement) bool {
+	if worker.State != "running" {
+		return false
+	}
+	if worker.Team != "" && worker.Team != team {
+		return false
+	}
+	if requirement.Platform != "" && worker.Platform != requirement.Platform {
+		return false
+	}
+	if len(requirement.Tags) == 0 {
+		return len(worker.Tags) == 0
+	}
+	return workerFilter{Tags: requirement.Tags}.matches(worker)
+}
+
+// unsatisfiedSteps returns a description of every step requirement that none of the given workers satisfies.
+func unsatisfiedSteps(config atc.Config, team string, workers []atc.Worker) []string {
+	var unsatisfied []string
+	for _, requirement := range pipelineStepRequirements(config) {
+		satisfied := false
+		for _, worker := range workers {
+			if canRunStep(worker, team, requirement) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			var constraints []string
+			if len(requirement.Tags) > 0 {
+				constraints = append(constraints, fmt.Sprintf("tags [%s]", strings.Join(requirement.Tags, ", ")))
+			} else {
+				constraints = append(constraints, "no tags")
+			}
+			if requirement.Platform != "" {
+				constraints = append(constraints, fmt.Sprintf("platform \"%s\"", requirement.Platform))
+			}
+			unsatisfied = append(unsatisfied, fmt.Sprintf("%s (%s)", requirement.Location, strings.Join(constraints, ", ")))
+		}
+	}
+	return unsatisfied
+}
+
+// resourcePipelineCustomizeDiff cross-checks the worker requirements of a pipeline config against the registered
+// workers if "worker_check" is enabled.
+func resourcePipelineCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	mode := d.Get("worker_check").(string)
+	if mode == "off" || !d.NewValueKnown("config") || !d.NewValueKnown("team") {
+		return nil
+	}
+
+	team := d.Get("team").(string)
+	configStr := d.Get("config").(string)
+
+	// The configured config is known at plan time even if only its hash is stored in the state, so it can always
+	// be checked as it is.
+	var config atc.Config
+	if err := atc.UnmarshalConfig([]byte(configStr), &config); err != nil {
+		return fmt.Errorf("unable to parse config of pipeline \"%s\": %v", d.Get("name").(string), err)
+	}
+
+	workers, err := m.(Config).Concourse().ListWorkers()
+	if err != nil {
+		return fmt.Errorf("unable to list workers: %v", err)
+	}
+
+	unsatisfied := unsatisfiedSteps(config, team, workers)
+	if len(unsatisfied) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("no running worker is able to run the following steps of pipeline \"%s\" in team \"%s\":\n  %s", d.Get("name").(string), team, strings.Join(unsatisfied, "\n  "))
+	if mode == "fail" {
+		return fmt.Errorf("%s", message)
+	}
+	log.Printf("[WARN] %s", message)
+	return nil
+}
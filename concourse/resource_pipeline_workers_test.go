@@ -0,0 +1,172 @@
+package concourse
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const workerCheckConfig = `
+resources:
+- name: repo
+  type: git
+  source: {uri: "https://example.com/repo.git"}
+jobs:
+- name: train
+  plan:
+  - get: repo
+  - in_parallel:
+    - task: gpu
+      tags: [gpu]
+      config:
+        platform: linux
+        run: {path: "true"}
+    - task: mac
+      config:
+        platform: darwin
+        run: {path: "true"}
+  ensure:
+    put: repo
+    tags: [gpu]
+`
+
+func TestUnsatisfiedSteps(t *testing.T) {
+	var config atc.Config
+	if err := atc.UnmarshalConfig([]byte(workerCheckConfig), &config); err != nil {
+		t.Fatalf("unable to parse config: %v", err)
+	}
+
+	if n := len(pipelineStepRequirements(config)); n != 5 {
+		t.Fatalf("expected 5 step requirements, got %d", n)
+	}
+
+	workers := []atc.Worker{
+		{Name: "linux", Platform: "linux", State: "running"},
+		{Name: "gpu", Platform: "linux", Tags: []string{"gpu"}, Team: "ml", State: "running"},
+		{Name: "darwin", Platform: "darwin", State: "stalled"},
+	}
+
+	expected := []string{
+		`job "train", step "gpu" (tags [gpu], platform "linux")`,
+		`job "train", step "mac" (no tags, platform "darwin")`,
+		`job "train", step "repo" (tags [gpu])`,
+	}
+	if actual := unsatisfiedSteps(config, "main", workers); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	expected = []string{
+		`job "train", step "mac" (no tags, platform "darwin")`,
+	}
+	if actual := unsatisfiedSteps(config, "ml", workers); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestResourcePipelineWorkerCheck(t *testing.T) {
+	linux := atc.Worker{Name: "linux", Platform: "linux", State: "running"}
+	gpu := atc.Worker{Name: "gpu", Platform: "linux", Tags: []string{"gpu"}, State: "running"}
+	darwin := atc.Worker{Name: "darwin", Platform: "darwin", State: "running"}
+
+	cases := []struct {
+		name        string
+		mode        string
+		workers     []atc.Worker
+		expectError bool
+		expectWarn  bool
+	}{
+		{name: "off", mode: "off", workers: []atc.Worker{linux}},
+		{name: "warn", mode: "warn", workers: []atc.Worker{linux}, expectWarn: true},
+		{name: "fail", mode: "fail", workers: []atc.Worker{linux}, expectError: true},
+		{name: "satisfied", mode: "fail", workers: []atc.Worker{linux, gpu, darwin}},
+	}
+
+	for _, c := range cases {
+		fake := newFakeATC()
+		fake.AddTeam("main", nil)
+		fake.Workers = c.workers
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		var logs bytes.Buffer
+		log.SetOutput(&logs)
+		_, err := resourcePipeline().Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"team":         "main",
+			"name":         "train",
+			"config":       workerCheckConfig,
+			"worker_check": c.mode,
+		}), cfg)
+		log.SetOutput(os.Stderr)
+
+		if c.expectError != (err != nil) {
+			t.Fatalf("%s: expected error %v, got %v", c.name, c.expectError, err)
+		}
+		if c.expectError && !strings.Contains(err.Error(), `job "train", step "gpu"`) {
+			t.Fatalf("%s: expected unsatisfied steps to be listed, got %v", c.name, err)
+		}
+		if warned := strings.Contains(logs.String(), "[WARN] no running worker"); warned != c.expectWarn {
+			t.Fatalf("%s: expected warning %v, got log %s", c.name, c.expectWarn, logs.String())
+		}
+		listed := false
+		for _, request := range fake.Requests {
+			listed = listed || request == "GET /api/v1/workers"
+		}
+		if listed != (c.mode != "off") {
+			t.Fatalf("%s: expected workers listed %v, got %v", c.name, c.mode != "off", fake.Requests)
+		}
+	}
+}
+
+func TestResourcePipelineWorkerCheckHashedConfig(t *testing.T) {
+	var config atc.Config
+	if err := atc.UnmarshalConfig([]byte(workerCheckConfig), &config); err != nil {
+		t.Fatalf("unable to parse config: %v", err)
+	}
+	hash, err := pipelineConfigHash(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	fake.AddPipeline("main", "train", config)
+	fake.Workers = []atc.Worker{{Name: "linux", Platform: "linux", State: "running"}}
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	pipeline, _ := fake.Pipeline("main", "train")
+	state := &terraform.InstanceState{
+		ID: "main/train",
+		Attributes: map[string]string{
+			"id":           "main/train",
+			"team":         "main",
+			"name":         "train",
+			"config":       hash,
+			"store_config": "hash",
+			"worker_check": "fail",
+			"paused":       "true",
+			"public":       "false",
+			"pipeline_id":  strconv.Itoa(pipeline.ID),
+		},
+	}
+
+	// The state only holds the hash of the unchanged config, the configured config is checked nevertheless.
+	_, err = resourcePipeline().Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"team":         "main",
+		"name":         "train",
+		"config":       workerCheckConfig,
+		"store_config": "hash",
+		"worker_check": "fail",
+		"paused":       true,
+	}), cfg)
+	if err == nil || !strings.Contains(err.Error(), `job "train", step "gpu"`) {
+		t.Fatalf("expected unsatisfied steps of the configured config, got %v", err)
+	}
+}
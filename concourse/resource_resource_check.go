@@ -0,0 +1,240 @@
+package concourse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// checkPollInterval is the interval in which the status of a triggered check is polled.
+const checkPollInterval = 2 * time.Second
+
+// waitForCheck blocks until the given check has finished or the deadline has been reached.
+func waitForCheck(client concourse.Client, check atc.Check, deadline time.Time) (atc.Check, error) {
+	for check.Status == "" || check.Status == "started" {
+		if time.Now().After(deadline) {
+			return check, fmt.Errorf("timed out waiting for check %d to finish", check.ID)
+		}
+		time.Sleep(checkPollInterval)
+
+		current, found, err := client.Check(strconv.Itoa(check.ID))
+		if err != nil {
+			return check, fmt.Errorf("unable to fetch check %d: %v", check.ID, err)
+		}
+		if !found {
+			return check, fmt.Errorf("check %d not found", check.ID)
+		}
+		check = current
+	}
+	return check, nil
+}
+
+func expandStringList(l []interface{}) []string {
+	result := make([]string, len(l))
+	for i, v := range l {
+		result[i] = v.(string)
+	}
+	return result
+}
+
+func resourceResourceCheckCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Config).Concourse()
+
+	teamName := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resources := expandStringList(d.Get("resources").([]interface{}))
+	resourceTypes := expandStringList(d.Get("resource_types").([]interface{}))
+	wait := d.Get("wait").(bool)
+
+	// Concourse only checks from the latest version if no version is given at all, an empty version checks from
+	// scratch.
+	var from atc.Version
+	if v := d.Get("from_version").(map[string]interface{}); len(v) > 0 {
+		from = expandVersion(v)
+	}
+
+	team := client.Team(teamName)
+
+	// Without explicit resources or resource types, all resources of the pipeline are checked.
+	if len(resources) == 0 && len(resourceTypes) == 0 {
+		all, err := team.ListResources(pipeline)
+		if err != nil {
+			return fmt.Errorf("unable to list resources of pipeline \"%s\" in team \"%s\": %v", pipeline, teamName, err)
+		}
+		for _, resource := range all {
+			resources = append(resources, resource.Name)
+		}
+	}
+
+	type triggered struct {
+		name  string
+		kind  string
+		check atc.Check
+	}
+	var checks []triggered
+
+	for _, name := range resources {
+		check, found, err := team.CheckResource(pipeline, name, from)
+		if err != nil {
+			return fmt.Errorf("unable to check resource \"%s\" of pipeline \"%s\": %v", name, pipeline, err)
+		}
+		if !found {
+			return fmt.Errorf("resource \"%s\" not found in pipeline \"%s\" of team \"%s\"", name, pipeline, teamName)
+		}
+		checks = append(checks, triggered{name: name, kind: "resource", check: check})
+	}
+
+	for _, name := range resourceTypes {
+		check, found, err := team.CheckResourceType(pipeline, name, from)
+		if err != nil {
+			return fmt.Errorf("unable to check resource type \"%s\" of pipeline \"%s\": %v", name, pipeline, err)
+		}
+		if !found {
+			return fmt.Errorf("resource type \"%s\" not found in pipeline \"%s\" of team \"%s\"", name, pipeline, teamName)
+		}
+		checks = append(checks, triggered{name: name, kind: "resource_type", check: check})
+	}
+
+	d.SetId(pipelineID(teamName, pipeline))
+
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
+	var errs []string
+	result := make([]interface{}, len(checks))
+	for i, c := range checks {
+		if wait {
+			check, err := waitForCheck(client, c.check, deadline)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s \"%s\": %v", c.kind, c.name, err))
+			} else if check.Status == "errored" {
+				errs = append(errs, fmt.Sprintf("%s \"%s\": %s", c.kind, c.name, check.CheckError))
+			}
+			c.check = check
+		}
+		result[i] = map[string]interface{}{
+			"name":        c.name,
+			"kind":        c.kind,
+			"check_id":    c.check.ID,
+			"status":      c.check.Status,
+			"check_error": c.check.CheckError,
+		}
+	}
+
+	if err := d.Set("checks", result); err != nil {
+		return fmt.Errorf("unable to set checks field: %v", err)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("checks of pipeline \"%s\" in team \"%s\" failed:\n%s", pipeline, teamName, strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func resourceResourceCheckRead(d *schema.ResourceData, m interface{}) error {
+	// Checks are one-off operations, there is nothing to refresh.
+	return nil
+}
+
+func resourceResourceCheckDelete(d *schema.ResourceData, m interface{}) error {
+	// Checks cannot be undone, so we only drop them from the state.
+	return nil
+}
+
+func resourceResourceCheck() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceResourceCheckCreate,
+		Read:   resourceResourceCheckRead,
+		Delete: resourceResourceCheckDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pipeline": {
+				Description: "Pipeline name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"resources": {
+				Description: "Names of the resources to check (all resources if neither resources nor resource types are given)",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"resource_types": {
+				Description: "Names of the resource types to check",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"from_version": {
+				Description: "Version to check from",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"triggers": {
+				Description: "Arbitrary values that trigger new checks when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"wait": {
+				Description: "Wait for the checks to finish and fail if any of them errors",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"checks": {
+				Description: "Triggered checks",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the checked resource or resource type",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"kind": {
+							Description: "Either \"resource\" or \"resource_type\"",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"check_id": {
+							Description: "Check ID",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Check status",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"check_error": {
+							Description: "Check error",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package concourse
+
+import (
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestResourceResourceCheckFromVersion(t *testing.T) {
+	cases := []struct {
+		name         string
+		config       map[string]interface{}
+		expectedBody string
+	}{
+		{
+			name:         "latest version",
+			config:       map[string]interface{}{},
+			expectedBody: `{"from":null}`,
+		},
+		{
+			name:         "from version",
+			config:       map[string]interface{}{"from_version": map[string]interface{}{"ref": "abc"}},
+			expectedBody: `{"from":{"ref":"abc"}}`,
+		},
+	}
+
+	for _, c := range cases {
+		fake := newFakeATC()
+		fake.AddTeam("team-a", nil)
+		fake.AddPipeline("team-a", "p1", atc.Config{
+			Resources: atc.ResourceConfigs{{Name: "repo", Type: "git"}},
+		})
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		raw := map[string]interface{}{"team": "team-a", "pipeline": "p1"}
+		for k, v := range c.config {
+			raw[k] = v
+		}
+
+		state, err := applyResource(t, resourceResourceCheck(), nil, raw, cfg)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+
+		body := fake.Bodies["POST /api/v1/teams/team-a/pipelines/p1/resources/repo/check"]
+		if body != c.expectedBody {
+			t.Fatalf("%s: expected check request %s, got %s", c.name, c.expectedBody, body)
+		}
+		if state.Attributes["checks.0.status"] != "succeeded" {
+			t.Fatalf("%s: expected check to succeed, got %v", c.name, state.Attributes)
+		}
+	}
+}
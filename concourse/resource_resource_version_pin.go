@@ -0,0 +1,286 @@
+package concourse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// pipelineResourceID builds the composite "<team>/<pipeline>/<resource>" ID of a pipeline resource.
+func pipelineResourceID(team, pipeline, resource string) string {
+	return fmt.Sprintf("%s/%s/%s", team, pipeline, resource)
+}
+
+// parsePipelineResourceID splits a composite "<team>/<pipeline>/<resource>" ID into its parts.
+func parsePipelineResourceID(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("id \"%s\" must be in the form <team>/<pipeline>/<resource>", id)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// expandVersion converts a Terraform map into a resource version.
+func expandVersion(v map[string]interface{}) atc.Version {
+	version := atc.Version{}
+	for key, value := range v {
+		version[key] = value.(string)
+	}
+	return version
+}
+
+// flattenVersion converts a resource version into a Terraform map.
+func flattenVersion(version atc.Version) map[string]interface{} {
+	v := make(map[string]interface{}, len(version))
+	for key, value := range version {
+		v[key] = value
+	}
+	return v
+}
+
+// versionMatches reports whether every field of the given filter is present with the same value in version.
+func versionMatches(version, filter atc.Version) bool {
+	for key, value := range filter {
+		if version[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// findResourceVersion looks up the (most recent) version of a pipeline resource that matches all fields of the
+// given version filter and, if id is not zero, has the given resource version ID. Versions are paged through
+// until a match has been found.
+func findResourceVersion(team concourse.Team, pipeline, resource string, filter atc.Version, id int) (atc.ResourceVersion, bool, error) {
+	page := concourse.Page{Limit: 100}
+	for {
+		versions, pagination, found, err := team.ResourceVersions(pipeline, resource, page, filter)
+		if err != nil {
+			return atc.ResourceVersion{}, false, fmt.Errorf("unable to list versions of resource \"%s\" in pipeline \"%s\": %v", resource, pipeline, err)
+		}
+		if !found {
+			return atc.ResourceVersion{}, false, fmt.Errorf("resource \"%s\" not found in pipeline \"%s\" of team \"%s\"", resource, pipeline, team.Name())
+		}
+		for _, version := range versions {
+			if versionMatches(version.Version, filter) && (id == 0 || version.ID == id) {
+				return version, true, nil
+			}
+		}
+		if pagination.Next == nil {
+			return atc.ResourceVersion{}, false, nil
+		}
+		page = *pagination.Next
+	}
+}
+
+func pinResourceVersion(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+	filter := expandVersion(d.Get("version").(map[string]interface{}))
+
+	team := m.(Config).Concourse().Team(teamName)
+
+	version, found, err := findResourceVersion(team, pipeline, resource, filter, 0)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no version of resource \"%s\" in pipeline \"%s\" of team \"%s\" matches %v", resource, pipeline, teamName, filter)
+	}
+
+	pinned, err := team.PinResourceVersion(pipeline, resource, version.ID)
+	if err != nil {
+		return fmt.Errorf("unable to pin version %d of resource \"%s\" in pipeline \"%s\": %v", version.ID, resource, pipeline, err)
+	}
+	if !pinned {
+		return fmt.Errorf("could not pin version %d of resource \"%s\" in pipeline \"%s\"", version.ID, resource, pipeline)
+	}
+	d.Set("version_id", version.ID)
+	return nil
+}
+
+func setPinComment(d *schema.ResourceData, m interface{}) error {
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+	comment := d.Get("pin_comment").(string)
+
+	if _, err := m.(Config).Concourse().Team(team).SetPinComment(pipeline, resource, comment); err != nil {
+		return fmt.Errorf("unable to set pin comment of resource \"%s\" in pipeline \"%s\": %v", resource, pipeline, err)
+	}
+	return nil
+}
+
+func resourceResourceVersionPinCreate(d *schema.ResourceData, m interface{}) error {
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+
+	r, found, err := m.(Config).Concourse().Team(team).Resource(pipeline, resource)
+	if err != nil {
+		return fmt.Errorf("unable to fetch resource \"%s\" of pipeline \"%s\" in team \"%s\": %v", resource, pipeline, team, err)
+	}
+	if !found {
+		return fmt.Errorf("resource \"%s\" not found in pipeline \"%s\" of team \"%s\"", resource, pipeline, team)
+	}
+	if r.PinnedInConfig {
+		return fmt.Errorf("resource \"%s\" of pipeline \"%s\" is pinned in the pipeline config and cannot be pinned via the API", resource, pipeline)
+	}
+
+	if err := pinResourceVersion(d, m); err != nil {
+		return err
+	}
+	if d.Get("pin_comment").(string) != "" {
+		if err := setPinComment(d, m); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(pipelineResourceID(team, pipeline, resource))
+	return resourceResourceVersionPinRead(d, m)
+}
+
+func resourceResourceVersionPinRead(d *schema.ResourceData, m interface{}) error {
+	team, pipeline, resource, err := parsePipelineResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(Config).Concourse().Team(team)
+
+	r, found, err := client.Resource(pipeline, resource)
+	if err != nil {
+		return fmt.Errorf("unable to fetch resource \"%s\" of pipeline \"%s\" in team \"%s\": %v", resource, pipeline, team, err)
+	}
+
+	// If the resource does not exist anymore or has been unpinned by hand, the pin is gone.
+	if !found || len(r.PinnedVersion) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	// The resource only reports the pinned version fields, its ID is looked up among the resource versions.
+	pinned, found, err := findResourceVersion(client, pipeline, resource, r.PinnedVersion, 0)
+	if err != nil {
+		return err
+	}
+	if found {
+		d.Set("version_id", pinned.ID)
+	} else {
+		d.Set("version_id", 0)
+	}
+
+	d.Set("team", team)
+	d.Set("pipeline", pipeline)
+	d.Set("resource", resource)
+	d.Set("pin_comment", r.PinComment)
+
+	// The pin has been changed by hand if the pinned version does not match the configured version fields
+	// anymore. Storing the actual version makes Terraform plan to re-pin the configured version.
+	filter := expandVersion(d.Get("version").(map[string]interface{}))
+	if !versionMatches(r.PinnedVersion, filter) {
+		d.Set("version", flattenVersion(r.PinnedVersion))
+	}
+
+	return nil
+}
+
+func resourceResourceVersionPinUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("version") {
+		if err := pinResourceVersion(d, m); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("pin_comment") {
+		if err := setPinComment(d, m); err != nil {
+			return err
+		}
+	}
+	return resourceResourceVersionPinRead(d, m)
+}
+
+func resourceResourceVersionPinDelete(d *schema.ResourceData, m interface{}) error {
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+
+	// A resource that no longer exists does not need to be unpinned.
+	if _, err := m.(Config).Concourse().Team(team).UnpinResource(pipeline, resource); err != nil {
+		return fmt.Errorf("unable to unpin resource \"%s\" of pipeline \"%s\" in team \"%s\": %v", resource, pipeline, team, err)
+	}
+	return nil
+}
+
+func resourceResourceVersionPinState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	team, pipeline, resource, err := parsePipelineResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r, found, err := m.(Config).Concourse().Team(team).Resource(pipeline, resource)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch resource \"%s\" of pipeline \"%s\" in team \"%s\": %v", resource, pipeline, team, err)
+	}
+	if !found || len(r.PinnedVersion) == 0 {
+		return nil, fmt.Errorf("no pinned resource found for %s", id)
+	}
+	d.Set("version", flattenVersion(r.PinnedVersion))
+
+	if err := resourceResourceVersionPinRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceResourceVersionPin() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceResourceVersionPinCreate,
+		Read:   resourceResourceVersionPinRead,
+		Update: resourceResourceVersionPinUpdate,
+		Delete: resourceResourceVersionPinDelete,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pipeline": {
+				Description: "Pipeline name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"resource": {
+				Description: "Resource name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"version": {
+				Description: "Version fields that identify the version to pin",
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"pin_comment": {
+				Description: "Pin comment",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"version_id": {
+				Description: "ID of the pinned resource version",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceResourceVersionPinState,
+		},
+	}
+}
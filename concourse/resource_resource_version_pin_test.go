@@ -0,0 +1,142 @@
+package concourse
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// newFakeResourceATC returns a fake ATC with the resource "repo" of pipeline "main/p1" and the given versions,
+// oldest first.
+func newFakeResourceATC(versions ...atc.Version) (*fakeATC, []atc.ResourceVersion) {
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	fake.AddPipeline("main", "p1", atc.Config{
+		Resources: atc.ResourceConfigs{{Name: "repo", Type: "git"}},
+	})
+	result := make([]atc.ResourceVersion, len(versions))
+	for i, version := range versions {
+		result[i] = fake.AddResourceVersion("main", "p1", "repo", version)
+	}
+	return fake, result
+}
+
+func TestFindResourceVersion(t *testing.T) {
+	var versions []atc.Version
+	for i := 0; i < 150; i++ {
+		versions = append(versions, atc.Version{"ref": strconv.Itoa(i), "branch": "master"})
+	}
+	fake, added := newFakeResourceATC(versions...)
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	team := cfg.Concourse().Team("main")
+
+	cases := []struct {
+		name       string
+		filter     atc.Version
+		id         int
+		expectedID int
+	}{
+		{name: "latest version", filter: atc.Version{}, expectedID: added[149].ID},
+		{name: "subset of fields", filter: atc.Version{"ref": "3"}, expectedID: added[3].ID},
+		{name: "all fields", filter: atc.Version{"ref": "3", "branch": "master"}, expectedID: added[3].ID},
+		{name: "by ID on a later page", filter: atc.Version{}, id: added[0].ID, expectedID: added[0].ID},
+		{name: "no match", filter: atc.Version{"ref": "3", "branch": "develop"}},
+	}
+
+	for _, c := range cases {
+		version, found, err := findResourceVersion(team, "p1", "repo", c.filter, c.id)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		if found != (c.expectedID != 0) || version.ID != c.expectedID {
+			t.Fatalf("%s: expected version %d, got %d (found: %v)", c.name, c.expectedID, version.ID, found)
+		}
+	}
+
+	if _, _, err := findResourceVersion(team, "p1", "missing", atc.Version{}, 0); err == nil {
+		t.Fatalf("expected missing resource to fail")
+	}
+}
+
+func TestResourceResourceVersionPin(t *testing.T) {
+	fake, versions := newFakeResourceATC(atc.Version{"ref": "a"}, atc.Version{"ref": "b"})
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourceResourceVersionPin()
+	raw := map[string]interface{}{
+		"team":        "main",
+		"pipeline":    "p1",
+		"resource":    "repo",
+		"version":     map[string]interface{}{"ref": "a"},
+		"pin_comment": "hold",
+	}
+
+	state, err := applyResource(t, r, nil, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["version_id"] != strconv.Itoa(versions[0].ID) {
+		t.Fatalf("expected version %d to be pinned, got %v", versions[0].ID, state.Attributes)
+	}
+	if pin := fake.Pins["main/p1/repo"]; !reflect.DeepEqual(pin.PinnedVersion, versions[0].Version) || pin.PinComment != "hold" {
+		t.Fatalf("expected version to be pinned with a comment, got %v", pin)
+	}
+
+	// Pinning another version outside of Terraform is detected and reverted.
+	fake.mu.Lock()
+	pin := fake.Pins["main/p1/repo"]
+	pin.PinnedVersion = versions[1].Version
+	fake.Pins["main/p1/repo"] = pin
+	fake.mu.Unlock()
+
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["version.ref"] != "b" || state.Attributes["version_id"] != strconv.Itoa(versions[1].ID) {
+		t.Fatalf("expected drift to be detected, got %v", state.Attributes)
+	}
+	state, err = applyResource(t, r, state, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pin := fake.Pins["main/p1/repo"]; !reflect.DeepEqual(pin.PinnedVersion, versions[0].Version) {
+		t.Fatalf("expected version to be pinned again, got %v", pin)
+	}
+	if state.Attributes["version_id"] != strconv.Itoa(versions[0].ID) {
+		t.Fatalf("expected version %d to be pinned again, got %v", versions[0].ID, state.Attributes)
+	}
+
+	// Unpinning outside of Terraform drops the pin from the state.
+	fake.mu.Lock()
+	fake.Pins["main/p1/repo"] = atc.Resource{}
+	fake.mu.Unlock()
+
+	if refreshed, err := r.Refresh(state, cfg); err != nil || (refreshed != nil && refreshed.ID != "") {
+		t.Fatalf("expected unpinned resource to be dropped from the state, got %v (%v)", refreshed, err)
+	}
+
+	// Destroying the resource unpins it.
+	state, err = applyResource(t, r, nil, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.Apply(state, &terraform.InstanceDiff{Destroy: true}, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pin := fake.Pins["main/p1/repo"]; len(pin.PinnedVersion) != 0 {
+		t.Fatalf("expected resource to be unpinned, got %v", pin)
+	}
+
+	// Versions that do not exist cannot be pinned.
+	raw["version"] = map[string]interface{}{"ref": "c"}
+	if _, err := applyResource(t, r, nil, raw, cfg); err == nil {
+		t.Fatalf("expected unknown version to fail")
+	}
+}
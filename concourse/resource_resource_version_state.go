@@ -0,0 +1,187 @@
+package concourse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceVersionID builds the composite "<team>/<pipeline>/<resource>/<version-id>" ID of a resource version.
+func resourceVersionID(team, pipeline, resource string, id int) string {
+	return fmt.Sprintf("%s/%s/%s/%d", team, pipeline, resource, id)
+}
+
+// parseResourceVersionID splits a composite "<team>/<pipeline>/<resource>/<version-id>" ID into its parts.
+func parseResourceVersionID(id string) (string, string, string, int, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", 0, fmt.Errorf("id \"%s\" must be in the form <team>/<pipeline>/<resource>/<version-id>", id)
+	}
+	versionID, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return "", "", "", 0, fmt.Errorf("invalid version ID in id \"%s\": %v", id, err)
+	}
+	return parts[0], parts[1], parts[2], versionID, nil
+}
+
+func setResourceVersionEnabled(d *schema.ResourceData, m interface{}, versionID int, enabled bool) error {
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+
+	concourse := m.(Config).Concourse().Team(team)
+
+	fn := concourse.DisableResourceVersion
+	if enabled {
+		fn = concourse.EnableResourceVersion
+	}
+	found, err := fn(pipeline, resource, versionID)
+	if err != nil {
+		return fmt.Errorf("unable to set enabled state of version %d of resource \"%s\" in pipeline \"%s\" to %v: %v", versionID, resource, pipeline, enabled, err)
+	}
+	if !found {
+		return fmt.Errorf("version %d of resource \"%s\" not found in pipeline \"%s\" of team \"%s\"", versionID, resource, pipeline, team)
+	}
+	return nil
+}
+
+func resourceResourceVersionStateCreate(d *schema.ResourceData, m interface{}) error {
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+	filter := expandVersion(d.Get("version").(map[string]interface{}))
+
+	version, found, err := findResourceVersion(m.(Config).Concourse().Team(team), pipeline, resource, filter, 0)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no version of resource \"%s\" in pipeline \"%s\" of team \"%s\" matches %v", resource, pipeline, team, filter)
+	}
+
+	if err := setResourceVersionEnabled(d, m, version.ID, d.Get("enabled").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(resourceVersionID(team, pipeline, resource, version.ID))
+	return resourceResourceVersionStateRead(d, m)
+}
+
+func resourceResourceVersionStateRead(d *schema.ResourceData, m interface{}) error {
+	team, pipeline, resource, versionID, err := parseResourceVersionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// The configured version fields narrow down the search, they are unknown while importing though.
+	filter := expandVersion(d.Get("version").(map[string]interface{}))
+
+	version, found, err := findResourceVersion(m.(Config).Concourse().Team(team), pipeline, resource, filter, versionID)
+	if err != nil {
+		return err
+	}
+
+	// If the version cannot be found, the resource (or its pipeline) has probably been deleted.
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("team", team)
+	d.Set("pipeline", pipeline)
+	d.Set("resource", resource)
+	d.Set("version_id", version.ID)
+	d.Set("enabled", version.Enabled)
+
+	// The configured fields are kept as they are, as they may identify the version by a subset of its fields.
+	if len(filter) == 0 {
+		d.Set("version", flattenVersion(version.Version))
+	}
+
+	return nil
+}
+
+func resourceResourceVersionStateUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("enabled") {
+		if err := setResourceVersionEnabled(d, m, d.Get("version_id").(int), d.Get("enabled").(bool)); err != nil {
+			return err
+		}
+	}
+	return resourceResourceVersionStateRead(d, m)
+}
+
+func resourceResourceVersionStateDelete(d *schema.ResourceData, m interface{}) error {
+	team := d.Get("team").(string)
+	pipeline := d.Get("pipeline").(string)
+	resource := d.Get("resource").(string)
+	versionID := d.Get("version_id").(int)
+
+	// Versions are enabled by default, so we restore that state. A version that no longer exists does not
+	// need to be enabled.
+	if _, err := m.(Config).Concourse().Team(team).EnableResourceVersion(pipeline, resource, versionID); err != nil {
+		return fmt.Errorf("unable to enable version %d of resource \"%s\" in pipeline \"%s\" of team \"%s\": %v", versionID, resource, pipeline, team, err)
+	}
+	return nil
+}
+
+func resourceResourceVersionStateState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if err := resourceResourceVersionStateRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("no resource version found for %s", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceResourceVersionState() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceResourceVersionStateCreate,
+		Read:   resourceResourceVersionStateRead,
+		Update: resourceResourceVersionStateUpdate,
+		Delete: resourceResourceVersionStateDelete,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pipeline": {
+				Description: "Pipeline name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"resource": {
+				Description: "Resource name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"version": {
+				Description: "Version fields that identify the resource version",
+				Type:        schema.TypeMap,
+				Required:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"enabled": {
+				Description: "Whether the resource version is enabled",
+				Type:        schema.TypeBool,
+				Required:    true,
+			},
+			"version_id": {
+				Description: "ID of the resource version",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceResourceVersionStateState,
+		},
+	}
+}
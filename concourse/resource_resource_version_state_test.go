@@ -0,0 +1,88 @@
+package concourse
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceResourceVersionState(t *testing.T) {
+	fake, versions := newFakeResourceATC(
+		atc.Version{"ref": "a", "branch": "master"},
+		atc.Version{"ref": "b", "branch": "master"},
+	)
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourceResourceVersionState()
+	raw := map[string]interface{}{
+		"team":     "main",
+		"pipeline": "p1",
+		"resource": "repo",
+		"version":  map[string]interface{}{"ref": "a"},
+		"enabled":  false,
+	}
+
+	state, err := applyResource(t, r, nil, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.ID != "main/p1/repo/"+strconv.Itoa(versions[0].ID) {
+		t.Fatalf("unexpected ID %s", state.ID)
+	}
+	if v, _ := fake.ResourceVersion("main", "p1", "repo", versions[0].ID); v.Enabled {
+		t.Fatalf("expected version to be disabled")
+	}
+	if v, _ := fake.ResourceVersion("main", "p1", "repo", versions[1].ID); !v.Enabled {
+		t.Fatalf("expected other versions to be kept enabled")
+	}
+
+	// Enabling the version outside of Terraform is detected and reverted.
+	fake.mu.Lock()
+	fake.ResourceVersions["main/p1/repo"][0].Enabled = true
+	fake.mu.Unlock()
+
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["enabled"] != "true" {
+		t.Fatalf("expected drift to be detected, got %v", state.Attributes)
+	}
+	if state.Attributes["version.%"] != "1" {
+		t.Fatalf("expected configured version fields to be kept, got %v", state.Attributes)
+	}
+	state, err = applyResource(t, r, state, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := fake.ResourceVersion("main", "p1", "repo", versions[0].ID); v.Enabled {
+		t.Fatalf("expected version to be disabled again")
+	}
+
+	// Destroying the resource enables the version again.
+	if _, err := r.Apply(state, &terraform.InstanceDiff{Destroy: true}, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := fake.ResourceVersion("main", "p1", "repo", versions[0].ID); !v.Enabled {
+		t.Fatalf("expected version to be enabled")
+	}
+
+	// Importing stores all fields of the version.
+	imported := &terraform.InstanceState{ID: "main/p1/repo/" + strconv.Itoa(versions[1].ID)}
+	imported, err = r.Refresh(imported, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if imported.Attributes["version.ref"] != "b" || imported.Attributes["version.branch"] != "master" {
+		t.Fatalf("expected version fields to be imported, got %v", imported.Attributes)
+	}
+
+	// Versions that do not exist cannot be managed.
+	raw["version"] = map[string]interface{}{"ref": "c"}
+	if _, err := applyResource(t, r, nil, raw, cfg); err == nil {
+		t.Fatalf("expected unknown version to fail")
+	}
+}
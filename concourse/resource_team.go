@@ -2,10 +2,14 @@ package concourse
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 // teamIDAsString converts a given numeric team ID, which is required, because Terraform resource data IDs must be
@@ -27,32 +31,104 @@ func teamExists(concourse concourse.Client, name string) (bool, error) {
 	return false, nil
 }
 
-func resourceTeamCreate(d *schema.ResourceData, m interface{}) error {
-	concourse := m.(Config).Concourse()
+// teamAuthConfig is implemented by both schema.ResourceData and schema.ResourceDiff, so that the auth
+// configuration of a team can be built at plan and at apply time.
+type teamAuthConfig interface {
+	Get(key string) interface{}
+}
 
-	name := d.Get("name").(string)
-	authUsers := d.Get("auth_users").([]interface{})
-	authGroups := d.Get("auth_groups").([]interface{})
+// expandTeamRoleAuth builds the authentication configuration of a single role from lists of users and groups.
+func expandTeamRoleAuth(users, groups interface{}) map[string][]string {
+	return map[string][]string{
+		"users":  expandStringList(users.([]interface{})),
+		"groups": expandStringList(groups.([]interface{})),
+	}
+}
 
-	var users, groups []string
+// expandTeamAuth builds the authentication configuration of a team by applying the configured "member" and
+// "owner" roles to the given current configuration. All other roles are kept as they are, and so is the "owner"
+// role if neither "owner_users" nor "owner_groups" is set.
+func expandTeamAuth(current atc.TeamAuth, d teamAuthConfig) atc.TeamAuth {
+	auth := atc.TeamAuth{}
+	for role, roleAuth := range current {
+		auth[role] = roleAuth
+	}
+	auth["member"] = expandTeamRoleAuth(d.Get("auth_users"), d.Get("auth_groups"))
+	if owner := expandTeamRoleAuth(d.Get("owner_users"), d.Get("owner_groups")); len(owner["users"])+len(owner["groups"]) > 0 {
+		auth["owner"] = owner
+	}
+	return auth
+}
 
-	for _, user := range authUsers {
-		users = append(users, user.(string))
+// teamAuthEqual reports whether two auth configurations grant the same roles to the same users and groups,
+// regardless of their order. Roles without any users and groups are ignored.
+func teamAuthEqual(a, b atc.TeamAuth) bool {
+	normalize := func(auth atc.TeamAuth) map[string][]string {
+		result := map[string][]string{}
+		for role, roleAuth := range auth {
+			for _, kind := range []string{"users", "groups"} {
+				entries := append([]string{}, roleAuth[kind]...)
+				sort.Strings(entries)
+				if len(entries) > 0 {
+					result[role+"/"+kind] = entries
+				}
+			}
+		}
+		return result
 	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
 
-	for _, group := range authGroups {
-		groups = append(groups, group.(string))
+// findTeam returns the team with the given numeric ID (as string) or name, or nil if there is no such team.
+func findTeam(concourse concourse.Client, id, name string) (*atc.Team, error) {
+	teams, err := concourse.ListTeams()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list teams: %v", err)
+	}
+	for i := range teams {
+		if (id != "" && id == teamIDAsString(teams[i].ID)) || (id == "" && name == teams[i].Name) {
+			return &teams[i], nil
+		}
 	}
+	return nil, nil
+}
+
+func resourceTeamCreate(d *schema.ResourceData, m interface{}) error {
+	concourse := m.(Config).Concourse()
+
+	name := d.Get("name").(string)
 
 	t := atc.Team{
 		Name: name,
-		Auth: map[string]map[string][]string{
-			"member": map[string][]string{
-				"users":  users,
-				"groups": groups,
-			},
-		},
+		Auth: expandTeamAuth(nil, d),
+	}
+
+	existing, err := findTeam(concourse, "", name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		// An existing team is adopted just like it is updated, i.e. roles that are not configured are kept.
+		t.Auth = expandTeamAuth(existing.Auth, d)
+
+		switch d.Get("adopt_existing").(string) {
+		case "fail":
+			return fmt.Errorf("team \"%s\" does already exist (set adopt_existing to adopt it)", name)
+		case "identical":
+			if !teamAuthEqual(existing.Auth, t.Auth) {
+				return fmt.Errorf("team \"%s\" does already exist with a different auth configuration (set adopt_existing to \"overwrite\" to adopt it anyway)", name)
+			}
+			d.SetId(teamIDAsString(existing.ID))
+			return resourceTeamRead(d, m)
+		}
+
+		if !d.Get("allow_lockout").(bool) {
+			if err := checkTeamAuthLockout(name, existing.Auth, t.Auth, m.(Config).UserInfo()); err != nil {
+				return err
+			}
+		}
 	}
+
 	team, created, updated, err := concourse.Team(name).CreateOrUpdate(t)
 	if err != nil {
 		return fmt.Errorf("could not create team: %v", err)
@@ -112,6 +188,14 @@ func resourceTeamRead(d *schema.ResourceData, m interface{}) error {
 				}
 			}
 
+			owner := team.Auth["owner"]
+			if err := d.Set("owner_users", owner["users"]); err != nil {
+				return fmt.Errorf("unable to set owner_users field: %v", err)
+			}
+			if err := d.Set("owner_groups", owner["groups"]); err != nil {
+				return fmt.Errorf("unable to set owner_groups field: %v", err)
+			}
+
 			return nil
 		}
 	}
@@ -126,59 +210,48 @@ func resourceTeamRead(d *schema.ResourceData, m interface{}) error {
 func resourceTeamUpdate(d *schema.ResourceData, m interface{}) error {
 	concourse := m.(Config).Concourse()
 
-	newName := ""
-	if d.HasChange("name") {
-		newName = d.Get("name").(string)
-	}
-	teams, err := concourse.ListTeams()
 	id := d.Id()
-	if err != nil {
-		return fmt.Errorf("unable to list teams: %v", err)
-	}
+	name := d.Get("name").(string)
 
-	var t *atc.Team
-	for _, team := range teams {
-		if id == teamIDAsString(team.ID) {
-			t = &team
-			oldName := team.Name
-			if newName != "" && oldName != newName {
-				t.Name = newName
-				_, err := concourse.Team(team.Name).RenameTeam(team.Name, newName)
-				if err != nil {
-					return err
-				}
-			}
-			return nil
-		}
+	t, err := findTeam(concourse, id, "")
+	if err != nil {
+		return err
 	}
-
 	if t == nil {
-		return fmt.Errorf("team with ID %s not found", d.Id())
+		return fmt.Errorf("team with ID %s not found", id)
 	}
 
-	if d.HasChange("auth_users") || d.HasChange("auth_groups") {
-		authUsers := d.Get("auth_users").([]interface{})
-		authGroups := d.Get("auth_groups").([]interface{})
-		var users, groups []string
+	authChanged := d.HasChange("auth_users") || d.HasChange("auth_groups") || d.HasChange("owner_users") || d.HasChange("owner_groups")
 
-		for _, user := range authUsers {
-			users = append(users, user.(string))
+	// Check the auth changes before renaming, so that a refused change does not leave a renamed team behind.
+	var auth atc.TeamAuth
+	if authChanged {
+		auth = expandTeamAuth(t.Auth, d)
+		if !d.Get("allow_lockout").(bool) {
+			if err := checkTeamAuthLockout(t.Name, t.Auth, auth, m.(Config).UserInfo()); err != nil {
+				return err
+			}
 		}
+	}
 
-		for _, group := range authGroups {
-			groups = append(groups, group.(string))
+	// The team is renamed first, so that the auth changes below are applied to the team with its new name.
+	if t.Name != name {
+		renamed, err := concourse.Team(t.Name).RenameTeam(t.Name, name)
+		if err != nil {
+			return fmt.Errorf("unable to rename team from \"%s\" to \"%s\": %v", t.Name, name, err)
 		}
-
-		t.Auth = map[string]map[string][]string{
-			"member": map[string][]string{
-				"users":  users,
-				"groups": groups,
-			},
+		if !renamed {
+			return fmt.Errorf("team with name \"%s\" not found", t.Name)
 		}
+		t.Name = name
+	}
+
+	if authChanged {
+		t.Auth = auth
 
 		_, created, updated, err := concourse.Team(t.Name).CreateOrUpdate(*t)
 		if err != nil {
-			return fmt.Errorf("could not create team: %v", err)
+			return fmt.Errorf("could not update team %s: %v", t.Name, err)
 		}
 		if !created && !updated {
 			return fmt.Errorf("could not create/update team %s: neither 'created' nor 'updated' was set to true", t.Name)
@@ -191,7 +264,38 @@ func resourceTeamUpdate(d *schema.ResourceData, m interface{}) error {
 func resourceTeamDelete(d *schema.ResourceData, m interface{}) error {
 	concourse := m.(Config).Concourse()
 	name := d.Get("name").(string)
-	return concourse.Team(name).DestroyTeam(name)
+
+	// The main team cannot be recreated by Terraform and owns the admin users, so it is never destroyed.
+	if name == atc.DefaultTeamName {
+		return fmt.Errorf("refusing to destroy team \"%s\"", name)
+	}
+
+	team := concourse.Team(name)
+
+	pipelines, err := team.ListPipelines()
+	if err != nil {
+		return fmt.Errorf("unable to list pipelines of team %s: %v", name, err)
+	}
+
+	if len(pipelines) > 0 {
+		names := make([]string, len(pipelines))
+		for i, pipeline := range pipelines {
+			names[i] = pipeline.Name
+		}
+		sort.Strings(names)
+
+		if !d.Get("force_destroy").(bool) {
+			return fmt.Errorf("refusing to destroy team %s, because it still has pipelines (set force_destroy to delete them): %s", name, strings.Join(names, ", "))
+		}
+
+		for _, pipeline := range names {
+			if _, err := team.DeletePipeline(pipeline); err != nil {
+				return fmt.Errorf("unable to delete pipeline %s of team %s: %v", pipeline, name, err)
+			}
+		}
+	}
+
+	return team.DestroyTeam(name)
 }
 
 func resourceTeamExists(d *schema.ResourceData, m interface{}) (bool, error) {
@@ -225,11 +329,12 @@ func resourceTeamState(d *schema.ResourceData, m interface{}) ([]*schema.Resourc
 
 func resourceTeam() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTeamCreate,
-		Read:   resourceTeamRead,
-		Update: resourceTeamUpdate,
-		Delete: resourceTeamDelete,
-		Exists: resourceTeamExists,
+		Create:        resourceTeamCreate,
+		Read:          resourceTeamRead,
+		Update:        resourceTeamUpdate,
+		Delete:        resourceTeamDelete,
+		Exists:        resourceTeamExists,
+		CustomizeDiff: resourceTeamCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "Team name",
@@ -256,6 +361,39 @@ func resourceTeam() *schema.Resource {
 				},
 				Optional: true,
 			},
+			"owner_users": {
+				Description: "Users that are granted the owner role, the role is left untouched if no owners are set",
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Computed:    true,
+			},
+			"owner_groups": {
+				Description: "Groups that are granted the owner role, the role is left untouched if no owners are set",
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Computed:    true,
+			},
+			"allow_lockout": {
+				Description: "Apply auth changes even if they remove the caller's owner role or the last owner of the team",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"adopt_existing": {
+				Description:  "What to do if the team already exists on creation: fail, overwrite or identical (adopt only if nothing would change)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "fail",
+				ValidateFunc: validation.StringInSlice([]string{"fail", "overwrite", "identical"}, false),
+			},
+			"force_destroy": {
+				Description: "Delete all pipelines of the team when destroying it",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: resourceTeamState,
@@ -0,0 +1,92 @@
+package concourse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// teamAuthUserMatches reports whether a "<connector>:<user>" auth entry refers to the caller. The user info of the
+// ATC does not include the connector, so only the user name and ID are compared.
+func teamAuthUserMatches(entry string, caller *SkyUserInfo) bool {
+	parts := strings.SplitN(entry, ":", 2)
+	user := parts[len(parts)-1]
+	return (caller.UserName != "" && strings.EqualFold(user, caller.UserName)) ||
+		(caller.UserID != "" && strings.EqualFold(user, caller.UserID))
+}
+
+// checkTeamAuthLockout returns an error if replacing the current auth configuration of a team by the proposed one
+// removes the last owner of the team or the owner role of the (non-admin) caller.
+func checkTeamAuthLockout(team string, current, proposed atc.TeamAuth, caller *SkyUserInfo) error {
+	currentOwner, proposedOwner := current["owner"], proposed["owner"]
+
+	if len(currentOwner["users"])+len(currentOwner["groups"]) > 0 && len(proposedOwner["users"])+len(proposedOwner["groups"]) == 0 {
+		return fmt.Errorf("refusing to remove the last owner of team \"%s\", nobody but admins could manage the team afterwards (set allow_lockout to apply the change anyway)", team)
+	}
+
+	if caller == nil || caller.IsAdmin || !containsString(caller.Teams[team], "owner") {
+		return nil
+	}
+
+	for _, user := range proposedOwner["users"] {
+		if teamAuthUserMatches(user, caller) {
+			return nil
+		}
+	}
+
+	// The groups of the caller are not part of the user info. If the caller is not listed as owner by name, it has
+	// to be an owner through one of the groups, so it keeps the role as long as none of them is removed.
+	listed := false
+	for _, user := range currentOwner["users"] {
+		if teamAuthUserMatches(user, caller) {
+			listed = true
+			break
+		}
+	}
+	if !listed {
+		keepsGroups := true
+		for _, group := range currentOwner["groups"] {
+			if !containsString(proposedOwner["groups"], group) {
+				keepsGroups = false
+				break
+			}
+		}
+		if keepsGroups {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("refusing to change the auth of team \"%s\", user \"%s\" would lose the owner role and could no longer manage the team (set allow_lockout to apply the change anyway)", team, caller.UserName)
+}
+
+// resourceTeamCustomizeDiff fails the plan if an auth change would lock the caller or all owners out of the team.
+// The same check is done again right before the change is applied.
+func resourceTeamCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("allow_lockout").(bool) || !d.NewValueKnown("name") || !d.NewValueKnown("auth_users") || !d.NewValueKnown("auth_groups") {
+		return nil
+	}
+
+	if d.Id() != "" {
+		// The owner fields are computed, so they are only unknown when creating a team without owners.
+		if !d.NewValueKnown("owner_users") || !d.NewValueKnown("owner_groups") {
+			return nil
+		}
+		if !d.HasChange("auth_users") && !d.HasChange("auth_groups") && !d.HasChange("owner_users") && !d.HasChange("owner_groups") {
+			return nil
+		}
+	}
+
+	// Only existing teams that are overwritten on creation can lock anybody out.
+	if d.Id() == "" && d.Get("adopt_existing").(string) != "overwrite" {
+		return nil
+	}
+
+	team, err := findTeam(m.(Config).Concourse(), d.Id(), d.Get("name").(string))
+	if err != nil || team == nil {
+		return err
+	}
+
+	return checkTeamAuthLockout(team.Name, team.Auth, expandTeamAuth(team.Auth, d), m.(Config).UserInfo())
+}
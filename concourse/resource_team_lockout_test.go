@@ -0,0 +1,87 @@
+package concourse
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestCheckTeamAuthLockout(t *testing.T) {
+	current := atc.TeamAuth{
+		"owner":  {"users": {"github:alice", "local:ci"}, "groups": {"github:org:admins"}},
+		"member": {"users": {"github:bob"}},
+	}
+	alice := &SkyUserInfo{UserName: "alice", Teams: map[string][]string{"team-a": {"owner"}}}
+	carol := &SkyUserInfo{UserName: "carol", Teams: map[string][]string{"team-a": {"owner"}}}
+	admin := &SkyUserInfo{UserName: "admin", IsAdmin: true}
+
+	cases := []struct {
+		name        string
+		owner       map[string][]string
+		caller      *SkyUserInfo
+		expectError bool
+	}{
+		{"owners unchanged", current["owner"], alice, false},
+		{"caller kept", map[string][]string{"users": {"github:alice"}}, alice, false},
+		{"caller removed", map[string][]string{"users": {"local:ci"}}, alice, true},
+		{"admin removed", map[string][]string{"users": {"local:ci"}}, admin, false},
+		{"all owners removed", map[string][]string{}, admin, true},
+		{"caller through group kept", map[string][]string{"groups": {"github:org:admins"}}, carol, false},
+		{"caller through group removed", map[string][]string{"users": {"github:alice"}}, carol, true},
+	}
+
+	for _, c := range cases {
+		proposed := atc.TeamAuth{"member": current["member"], "owner": c.owner}
+		err := checkTeamAuthLockout("team-a", current, proposed, c.caller)
+		if c.expectError != (err != nil) {
+			t.Fatalf("%s: expected error %v, got %v", c.name, c.expectError, err)
+		}
+	}
+}
+
+func TestResourceTeamLockoutPlan(t *testing.T) {
+	for _, allowLockout := range []bool{false, true} {
+		fake := newFakeATC()
+		fake.UserInfo = SkyUserInfo{UserName: "ci", Teams: map[string][]string{"team-a": {"owner"}}}
+		id := fake.AddTeam("team-a", map[string]map[string][]string{
+			"owner": {"users": {"local:ci", "github:alice"}},
+		})
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		state := &terraform.InstanceState{
+			ID: strconv.Itoa(id),
+			Attributes: map[string]string{
+				"id":             strconv.Itoa(id),
+				"name":           "team-a",
+				"owner_users.#":  "2",
+				"owner_users.0":  "local:ci",
+				"owner_users.1":  "github:alice",
+				"owner_groups.#": "0",
+			},
+		}
+		raw := map[string]interface{}{
+			"name":          "team-a",
+			"owner_users":   []interface{}{"github:alice"},
+			"allow_lockout": allowLockout,
+		}
+
+		_, err := applyResource(t, resourceTeam(), state, raw, cfg)
+		if allowLockout {
+			if err != nil {
+				t.Fatalf("unexpected error with allow_lockout: %v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), "would lose the owner role") {
+			t.Fatalf("expected lockout error, got %v", err)
+		}
+		team, _ := fake.Team("team-a")
+		if len(team.Auth["owner"]["users"]) != 2 {
+			t.Fatalf("expected owners to be kept, got %v", team.Auth["owner"])
+		}
+	}
+}
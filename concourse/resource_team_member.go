@@ -0,0 +1,216 @@
+package concourse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// teamRoles lists the roles that can be granted to the users and groups of a team.
+var teamRoles = []string{"owner", "member", "pipeline-operator", "viewer"}
+
+// teamMemberID builds the composite "<team>/<role>/<user|group>/<name>" ID of a team member.
+func teamMemberID(team, role, kind, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", team, role, kind, name)
+}
+
+// parseTeamMemberID splits a composite "<team>/<role>/<user|group>/<name>" ID into its parts.
+func parseTeamMemberID(id string) (string, string, string, string, error) {
+	parts := strings.SplitN(id, "/", 4)
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || (parts[2] != "user" && parts[2] != "group") || parts[3] == "" {
+		return "", "", "", "", fmt.Errorf("id \"%s\" must be in the form <team>/<role>/<user|group>/<name>", id)
+	}
+	return parts[0], parts[1], parts[2], parts[3], nil
+}
+
+// updateTeamMember adds or removes a single user or group of a team role. The auth configuration of the team is
+// read right before it is written, so that all other entries, including those managed elsewhere, are kept.
+func updateTeamMember(m interface{}, teamName, role, kind, name string, add, allowLockout bool) error {
+	concourse := m.(Config).Concourse()
+
+	team, err := findTeam(concourse, "", teamName)
+	if err != nil {
+		return err
+	}
+	if team == nil {
+		return fmt.Errorf("team \"%s\" not found", teamName)
+	}
+
+	// The auth configuration uses the plural form of user and group as keys.
+	key := kind + "s"
+
+	auth := atc.TeamAuth{}
+	for r, roleAuth := range team.Auth {
+		auth[r] = roleAuth
+	}
+
+	// Entries that already exist are not managed by Terraform yet. Adopting them would remove them on destroy, so
+	// they have to be imported instead.
+	if add && containsString(auth[role][key], name) {
+		return fmt.Errorf("%s \"%s\" already has role \"%s\" in team \"%s\", import it using the id \"%s\"", kind, name, role, teamName, teamMemberID(teamName, role, kind, name))
+	}
+
+	var entries []string
+	for _, entry := range auth[role][key] {
+		if entry != name {
+			entries = append(entries, entry)
+		}
+	}
+	if add {
+		entries = append(entries, name)
+	}
+
+	roleAuth := map[string][]string{}
+	for k, v := range auth[role] {
+		roleAuth[k] = v
+	}
+	roleAuth[key] = entries
+	auth[role] = roleAuth
+	if len(roleAuth["users"])+len(roleAuth["groups"]) == 0 {
+		delete(auth, role)
+	}
+
+	if !add && !allowLockout {
+		if err := checkTeamAuthLockout(teamName, team.Auth, auth, m.(Config).UserInfo()); err != nil {
+			return err
+		}
+	}
+
+	team.Auth = auth
+	_, created, updated, err := concourse.Team(teamName).CreateOrUpdate(*team)
+	if err != nil {
+		return fmt.Errorf("could not update team %s: %v", teamName, err)
+	}
+	if !created && !updated {
+		return fmt.Errorf("could not create/update team %s: neither 'created' nor 'updated' was set to true", teamName)
+	}
+	return nil
+}
+
+func resourceTeamMemberCreate(d *schema.ResourceData, m interface{}) error {
+	team := d.Get("team").(string)
+	role := d.Get("role").(string)
+
+	kind, name := "user", d.Get("user").(string)
+	if name == "" {
+		kind, name = "group", d.Get("group").(string)
+	}
+	if name == "" {
+		return fmt.Errorf("either user or group must be set")
+	}
+
+	if err := updateTeamMember(m, team, role, kind, name, true, d.Get("allow_lockout").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(teamMemberID(team, role, kind, name))
+	return resourceTeamMemberRead(d, m)
+}
+
+func resourceTeamMemberRead(d *schema.ResourceData, m interface{}) error {
+	teamName, role, kind, name, err := parseTeamMemberID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	team, err := findTeam(m.(Config).Concourse(), "", teamName)
+	if err != nil {
+		return err
+	}
+
+	// If the team or the entry cannot be found, it has been removed outside of Terraform.
+	if team == nil || !containsString(team.Auth[role][kind+"s"], name) {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("team", teamName)
+	d.Set("role", role)
+	d.Set(kind, name)
+
+	return nil
+}
+
+func resourceTeamMemberUpdate(d *schema.ResourceData, m interface{}) error {
+	// Only allow_lockout can change in place, and it is only used when the entry is removed.
+	return resourceTeamMemberRead(d, m)
+}
+
+func resourceTeamMemberDelete(d *schema.ResourceData, m interface{}) error {
+	teamName, role, kind, name, err := parseTeamMemberID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	team, err := findTeam(m.(Config).Concourse(), "", teamName)
+	if err != nil {
+		return err
+	}
+	if team == nil {
+		return nil
+	}
+
+	return updateTeamMember(m, teamName, role, kind, name, false, d.Get("allow_lockout").(bool))
+}
+
+func resourceTeamMemberState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if err := resourceTeamMemberRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("no team member found for %s", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTeamMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTeamMemberCreate,
+		Read:   resourceTeamMemberRead,
+		Update: resourceTeamMemberUpdate,
+		Delete: resourceTeamMemberDelete,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"role": {
+				Description:  "Role to grant: owner, member, pipeline-operator or viewer",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "member",
+				ValidateFunc: validation.StringInSlice(teamRoles, false),
+			},
+			"user": {
+				Description:   "User to grant the role to, e.g. github:alice",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"group"},
+			},
+			"group": {
+				Description:   "Group to grant the role to, e.g. github:my-org:my-team",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"user"},
+			},
+			"allow_lockout": {
+				Description: "Remove the entry even if this removes the caller's owner role or the last owner of the team",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceTeamMemberState,
+		},
+	}
+}
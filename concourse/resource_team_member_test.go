@@ -0,0 +1,100 @@
+package concourse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestParseTeamMemberID(t *testing.T) {
+	team, role, kind, name, err := parseTeamMemberID("team-a/viewer/group/github:org:my/team")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if team != "team-a" || role != "viewer" || kind != "group" || name != "github:org:my/team" {
+		t.Fatalf("unexpected parts: %s, %s, %s, %s", team, role, kind, name)
+	}
+
+	for _, id := range []string{"team-a/viewer/github:alice", "team-a/viewer/member/github:alice", "/viewer/user/github:alice"} {
+		if _, _, _, _, err := parseTeamMemberID(id); err == nil {
+			t.Fatalf("expected error for id %s", id)
+		}
+	}
+}
+
+func TestResourceTeamMember(t *testing.T) {
+	fake := newFakeATC()
+	fake.AddTeam("team-a", map[string]map[string][]string{
+		"owner":  {"users": {"local:admin"}},
+		"member": {"users": {"github:alice"}},
+	})
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourceTeamMember()
+	state, err := applyResource(t, r, nil, map[string]interface{}{
+		"team": "team-a",
+		"user": "github:bob",
+	}, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.ID != "team-a/member/user/github:bob" {
+		t.Fatalf("unexpected ID %s", state.ID)
+	}
+
+	team, _ := fake.Team("team-a")
+	if !reflect.DeepEqual(team.Auth["member"]["users"], []string{"github:alice", "github:bob"}) {
+		t.Fatalf("expected bob to be added to the members, got %v", team.Auth["member"])
+	}
+	if !reflect.DeepEqual(team.Auth["owner"]["users"], []string{"local:admin"}) {
+		t.Fatalf("expected owners to be kept, got %v", team.Auth["owner"])
+	}
+
+	// Existing entries have to be imported, so that destroying the resource does not remove them.
+	if _, err := applyResource(t, r, nil, map[string]interface{}{
+		"team": "team-a",
+		"user": "github:alice",
+	}, cfg); err == nil || !strings.Contains(err.Error(), "import") {
+		t.Fatalf("expected existing entry to be rejected, got %v", err)
+	}
+
+	// Removing the entry outside of Terraform is detected on refresh.
+	fake.mu.Lock()
+	for i := range fake.Teams {
+		if fake.Teams[i].Name == "team-a" {
+			fake.Teams[i].Auth["member"] = map[string][]string{"users": {"github:alice"}}
+		}
+	}
+	fake.mu.Unlock()
+
+	refreshed, err := r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refreshed != nil && refreshed.ID != "" {
+		t.Fatalf("expected removed entry to be dropped from the state, got %v", refreshed)
+	}
+
+	// Destroying the resource only removes its own entry.
+	state, err = applyResource(t, r, nil, map[string]interface{}{
+		"team": "team-a",
+		"role": "viewer",
+		"user": "github:bob",
+	}, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.Apply(state, &terraform.InstanceDiff{Destroy: true}, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	team, _ = fake.Team("team-a")
+	if _, ok := team.Auth["viewer"]; ok {
+		t.Fatalf("expected empty viewer role to be removed, got %v", team.Auth["viewer"])
+	}
+	if !reflect.DeepEqual(team.Auth["member"]["users"], []string{"github:alice"}) {
+		t.Fatalf("expected members to be kept, got %v", team.Auth["member"])
+	}
+}
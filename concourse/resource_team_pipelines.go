@@ -0,0 +1,322 @@
+package concourse
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/hashicorp/terraform/helper/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// setPipelineFlags pauses/unpauses and exposes/hides a pipeline if its current state differs from the given one.
+func setPipelineFlags(team concourse.Team, pipeline atc.Pipeline, paused, public bool) error {
+	if pipeline.Paused != paused {
+		fn := team.UnpausePipeline
+		if paused {
+			fn = team.PausePipeline
+		}
+		if _, err := fn(pipeline.Name); err != nil {
+			return fmt.Errorf("unable to set paused state of pipeline \"%s\" to %v: %v", pipeline.Name, paused, err)
+		}
+	}
+
+	if pipeline.Public != public {
+		fn := team.HidePipeline
+		if public {
+			fn = team.ExposePipeline
+		}
+		if _, err := fn(pipeline.Name); err != nil {
+			return fmt.Errorf("unable to set public state of pipeline \"%s\" to %v: %v", pipeline.Name, public, err)
+		}
+	}
+
+	return nil
+}
+
+// declaredPipelines returns the pipelines of the "pipeline" blocks by name.
+func declaredPipelines(d *schema.ResourceData) map[string]map[string]interface{} {
+	result := map[string]map[string]interface{}{}
+	for _, p := range d.Get("pipeline").(*schema.Set).List() {
+		pipeline := p.(map[string]interface{})
+		result[pipeline["name"].(string)] = pipeline
+	}
+	return result
+}
+
+func resourceTeamPipelinesApply(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Get("team").(string)
+	team := m.(Config).Concourse().Team(teamName)
+
+	declared := declaredPipelines(d)
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		config := declared[name]["config"].(string)
+
+		var newConfig atc.Config
+		if err := atc.UnmarshalConfig([]byte(config), &newConfig); err != nil {
+			return fmt.Errorf("unable to parse config of pipeline \"%s\": %v", name, err)
+		}
+
+		existingConfig, configVersion, found, err := team.PipelineConfig(name)
+		if err != nil {
+			return fmt.Errorf("unable to fetch configuration of pipeline \"%s\" of team \"%s\": %v", name, teamName, err)
+		}
+		if !found {
+			configVersion = "1"
+		}
+
+		if !found || existingConfig.Diff(&bytes.Buffer{}, newConfig) {
+			created, updated, _, err := team.CreateOrUpdatePipelineConfig(name, configVersion, []byte(config), false)
+			if err != nil {
+				return fmt.Errorf("unable to set configuration of pipeline \"%s\" of team \"%s\": %v", name, teamName, err)
+			}
+			if !created && !updated {
+				return fmt.Errorf("configuration of pipeline \"%s\" of team \"%s\" has neither been created nor updated", name, teamName)
+			}
+		}
+
+		pipeline, found, err := team.Pipeline(name)
+		if err != nil || !found {
+			return fmt.Errorf("unable to read pipeline \"%s\" of team \"%s\" after setting its configuration: %v", name, teamName, err)
+		}
+
+		if err := setPipelineFlags(team, pipeline, declared[name]["paused"].(bool), declared[name]["public"].(bool)); err != nil {
+			return err
+		}
+	}
+
+	// Only the pipelines whose removal has been planned are deleted, i.e. the unmanaged pipelines known at plan
+	// time and the pipelines that are no longer declared. Pipelines that have been added since then are listed by
+	// the next plan. Concourse cannot archive pipelines.
+	oldUnmanaged, _ := d.GetChange("unmanaged_pipelines")
+	removed := expandStringList(oldUnmanaged.([]interface{}))
+	oldPipelines, _ := d.GetChange("pipeline")
+	for _, p := range oldPipelines.(*schema.Set).List() {
+		name := p.(map[string]interface{})["name"].(string)
+		if _, ok := declared[name]; !ok && !containsString(removed, name) {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+
+	for _, name := range removed {
+		if _, ok := declared[name]; ok {
+			continue
+		}
+		if _, err := team.DeletePipeline(name); err != nil {
+			return fmt.Errorf("unable to delete unmanaged pipeline \"%s\" of team \"%s\": %v", name, teamName, err)
+		}
+	}
+
+	d.SetId(teamName)
+	return resourceTeamPipelinesRead(d, m)
+}
+
+func resourceTeamPipelinesRead(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Id()
+	client := m.(Config).Concourse()
+
+	// If the team has been deleted, so have all of its pipelines.
+	if exists, err := teamExists(client, teamName); err != nil {
+		return err
+	} else if !exists {
+		d.SetId("")
+		return nil
+	}
+
+	team := client.Team(teamName)
+
+	pipelines, err := team.ListPipelines()
+	if err != nil {
+		return fmt.Errorf("unable to list pipelines of team \"%s\": %v", teamName, err)
+	}
+
+	declared := declaredPipelines(d)
+
+	// While importing, all pipelines of the team are declared.
+	importing := d.Get("team").(string) == ""
+
+	result := make([]interface{}, 0, len(pipelines))
+	unmanaged := make([]interface{}, 0)
+	for _, pipeline := range pipelines {
+		last, ok := declared[pipeline.Name]
+		if !ok && !importing {
+			unmanaged = append(unmanaged, pipeline.Name)
+			continue
+		}
+
+		currentConfig, _, _, err := team.PipelineConfig(pipeline.Name)
+		if err != nil {
+			return fmt.Errorf("unable to read configuration of pipeline \"%s\": %v", pipeline.Name, err)
+		}
+
+		// The last known config is kept as long as it is equivalent to the current one, so that formatting and
+		// comments do not cause a diff.
+		config := ""
+		if ok {
+			config = last["config"].(string)
+		}
+
+		var lastConfig atc.Config
+		if err := atc.UnmarshalConfig([]byte(config), &lastConfig); err != nil {
+			return fmt.Errorf("error parsing last known config of pipeline \"%s\": %v", pipeline.Name, err)
+		}
+
+		if config == "" || lastConfig.Diff(&bytes.Buffer{}, currentConfig) {
+			configBytes, err := yaml.Marshal(currentConfig)
+			if err != nil {
+				return fmt.Errorf("unable to marshal config: %v", err)
+			}
+			config = string(configBytes)
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":   pipeline.Name,
+			"config": config,
+			"paused": pipeline.Paused,
+			"public": pipeline.Public,
+		})
+	}
+
+	d.Set("team", teamName)
+
+	if err := d.Set("pipeline", result); err != nil {
+		return fmt.Errorf("unable to set pipeline field: %v", err)
+	}
+
+	if err := d.Set("unmanaged_pipelines", unmanaged); err != nil {
+		return fmt.Errorf("unable to set unmanaged_pipelines field: %v", err)
+	}
+
+	return nil
+}
+
+func resourceTeamPipelinesDelete(d *schema.ResourceData, m interface{}) error {
+	teamName := d.Get("team").(string)
+	team := m.(Config).Concourse().Team(teamName)
+
+	for name := range declaredPipelines(d) {
+		if _, err := team.DeletePipeline(name); err != nil {
+			return fmt.Errorf("unable to delete pipeline \"%s\" of team \"%s\": %v", name, teamName, err)
+		}
+	}
+	return nil
+}
+
+// resourceTeamPipelinesCustomizeDiff plans the removal of all pipelines that have not been declared, so that they
+// are listed in the plan.
+func resourceTeamPipelinesCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		// Pipelines that exist before the resource is created are not known to the state yet, so they cannot be
+		// listed in the plan. Instead of deleting them unnoticed, we ask for the resource to be imported first.
+		if !d.NewValueKnown("team") {
+			return nil
+		}
+		client := m.(Config).Concourse()
+		if exists, err := teamExists(client, d.Get("team").(string)); err != nil || !exists {
+			return err
+		}
+		pipelines, err := client.Team(d.Get("team").(string)).ListPipelines()
+		if err != nil {
+			return fmt.Errorf("unable to list pipelines of team \"%s\": %v", d.Get("team").(string), err)
+		}
+		declared := map[string]bool{}
+		for _, p := range d.Get("pipeline").(*schema.Set).List() {
+			declared[p.(map[string]interface{})["name"].(string)] = true
+		}
+		var unmanaged []string
+		for _, pipeline := range pipelines {
+			if !declared[pipeline.Name] {
+				unmanaged = append(unmanaged, pipeline.Name)
+			}
+		}
+		if len(unmanaged) > 0 {
+			return fmt.Errorf("team \"%s\" already has pipelines that have not been declared, import the resource to plan their removal: %s", d.Get("team").(string), strings.Join(unmanaged, ", "))
+		}
+		return nil
+	}
+
+	if len(d.Get("unmanaged_pipelines").([]interface{})) > 0 {
+		return d.SetNew("unmanaged_pipelines", []interface{}{})
+	}
+	return nil
+}
+
+func resourceTeamPipelinesState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	teamName := d.Id()
+	if err := resourceTeamPipelinesRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("no team found for %s", teamName)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTeamPipelines() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceTeamPipelinesApply,
+		Read:          resourceTeamPipelinesRead,
+		Update:        resourceTeamPipelinesApply,
+		Delete:        resourceTeamPipelinesDelete,
+		CustomizeDiff: resourceTeamPipelinesCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Description: "Team name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pipeline": {
+				Description: "All pipelines of the team",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Pipeline name",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"config": {
+							Description: "Pipeline configuration YAML",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"paused": {
+							Description: "Paused",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"public": {
+							Description: "Public",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+			"unmanaged_pipelines": {
+				Description: "Pipelines of the team that have not been declared and will be deleted",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceTeamPipelinesState,
+		},
+	}
+}
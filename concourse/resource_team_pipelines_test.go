@@ -0,0 +1,101 @@
+package concourse
+
+import (
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceTeamPipelines(t *testing.T) {
+	config := "jobs:\n- name: hello\n  plan:\n  - task: hello\n    config:\n      platform: linux\n      run: {path: echo}\n"
+
+	var existing atc.Config
+	if err := atc.UnmarshalConfig([]byte(config), &existing); err != nil {
+		t.Fatalf("unable to parse config: %v", err)
+	}
+
+	fake := newFakeATC()
+	fake.AddTeam("main", nil)
+	fake.AddPipeline("main", "a", existing)
+	fake.AddPipeline("main", "stray", existing)
+	cfg, stop := fake.Start(t)
+	defer stop()
+
+	r := resourceTeamPipelines()
+	raw := map[string]interface{}{
+		"team": "main",
+		"pipeline": []interface{}{
+			map[string]interface{}{"name": "a", "config": config},
+			map[string]interface{}{"name": "b", "config": config, "public": true},
+		},
+	}
+
+	// Pipelines that exist before the resource is created are never deleted unnoticed.
+	if _, err := applyResource(t, r, nil, raw, cfg); err == nil {
+		t.Fatalf("expected undeclared pipeline to fail the plan")
+	}
+	if _, ok := fake.Pipeline("main", "stray"); !ok {
+		t.Fatalf("expected undeclared pipeline to be kept")
+	}
+
+	fake.mu.Lock()
+	fake.Pipelines["main"] = fake.Pipelines["main"][:1]
+	fake.mu.Unlock()
+
+	state, err := applyResource(t, r, nil, raw, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, ok := fake.Pipeline("main", "b")
+	if !ok || b.Paused || !b.Public {
+		t.Fatalf("expected pipeline b to be created unpaused and public, got %v", b)
+	}
+
+	// Pipelines added outside of Terraform are listed on refresh and removed on the next apply.
+	fake.AddPipeline("main", "stray", existing)
+	state, err = r.Refresh(state, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Attributes["unmanaged_pipelines.#"] != "1" || state.Attributes["unmanaged_pipelines.0"] != "stray" {
+		t.Fatalf("expected stray pipeline to be unmanaged, got %v", state.Attributes)
+	}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Pipelines that are added between plan and apply are not deleted, as they have not been planned.
+	fake.AddPipeline("main", "late", existing)
+
+	state, err = r.Apply(state, diff, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := fake.Pipeline("main", "stray"); ok {
+		t.Fatalf("expected stray pipeline to be deleted")
+	}
+	if _, ok := fake.Pipeline("main", "late"); !ok {
+		t.Fatalf("expected pipeline added after the plan to be kept")
+	}
+	if state.Attributes["unmanaged_pipelines.#"] != "1" || state.Attributes["unmanaged_pipelines.0"] != "late" {
+		t.Fatalf("expected pipeline added after the plan to be unmanaged, got %v", state.Attributes)
+	}
+	if _, ok := fake.Pipeline("main", "a"); !ok {
+		t.Fatalf("expected pipeline a to be kept")
+	}
+
+	// Pipelines that are no longer declared are deleted as well.
+	raw["pipeline"] = raw["pipeline"].([]interface{})[:1]
+	if _, err := applyResource(t, r, state, raw, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := fake.Pipeline("main", "b"); ok {
+		t.Fatalf("expected pipeline b to be deleted")
+	}
+	if names := fake.PipelineNames("main"); len(names) != 1 || names[0] != "a" {
+		t.Fatalf("expected only pipeline a to be left, got %v", names)
+	}
+}
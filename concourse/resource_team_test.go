@@ -0,0 +1,179 @@
+package concourse
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceTeamUpdate(t *testing.T) {
+	owners := map[string][]string{"users": {"local:admin"}}
+
+	cases := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedName  string
+		expectedUsers []string
+	}{
+		{
+			name:          "rename and auth change",
+			config:        map[string]interface{}{"name": "new", "auth_users": []interface{}{"github:bob"}},
+			expectedName:  "new",
+			expectedUsers: []string{"github:bob"},
+		},
+		{
+			name:          "auth change only",
+			config:        map[string]interface{}{"name": "old", "auth_users": []interface{}{"github:bob"}},
+			expectedName:  "old",
+			expectedUsers: []string{"github:bob"},
+		},
+		{
+			name:          "rename only",
+			config:        map[string]interface{}{"name": "new", "auth_users": []interface{}{"github:alice"}},
+			expectedName:  "new",
+			expectedUsers: []string{"github:alice"},
+		},
+	}
+
+	for _, c := range cases {
+		fake := newFakeATC()
+		id := fake.AddTeam("old", map[string]map[string][]string{
+			"owner":  owners,
+			"member": {"users": {"github:alice"}},
+		})
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		state := &terraform.InstanceState{
+			ID: strconv.Itoa(id),
+			Attributes: map[string]string{
+				"id":            strconv.Itoa(id),
+				"name":          "old",
+				"auth_users.#":  "1",
+				"auth_users.0":  "github:alice",
+				"auth_groups.#": "0",
+			},
+		}
+
+		newState, err := applyResource(t, resourceTeam(), state, c.config, cfg)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+
+		if newState.ID != strconv.Itoa(id) {
+			t.Fatalf("%s: expected ID %d, got %s", c.name, id, newState.ID)
+		}
+		if newState.Attributes["name"] != c.expectedName {
+			t.Fatalf("%s: expected name %s in state, got %s", c.name, c.expectedName, newState.Attributes["name"])
+		}
+		if newState.Attributes["auth_users.0"] != c.expectedUsers[0] {
+			t.Fatalf("%s: expected auth_users %v in state, got %s", c.name, c.expectedUsers, newState.Attributes["auth_users.0"])
+		}
+
+		team, ok := fake.Team(c.expectedName)
+		if !ok {
+			t.Fatalf("%s: team %s not found", c.name, c.expectedName)
+		}
+		if !reflect.DeepEqual(team.Auth["member"]["users"], c.expectedUsers) {
+			t.Fatalf("%s: expected member users %v, got %v", c.name, c.expectedUsers, team.Auth["member"]["users"])
+		}
+		if !reflect.DeepEqual(team.Auth["owner"], owners) {
+			t.Fatalf("%s: expected owner role to be kept, got %v", c.name, team.Auth["owner"])
+		}
+	}
+}
+
+func TestResourceTeamDelete(t *testing.T) {
+	cases := []struct {
+		name         string
+		team         string
+		pipelines    []string
+		forceDestroy bool
+		expectError  bool
+	}{
+		{name: "empty team", team: "team-a"},
+		{name: "team with pipelines", team: "team-a", pipelines: []string{"p1", "p2"}, expectError: true},
+		{name: "force destroy", team: "team-a", pipelines: []string{"p1", "p2"}, forceDestroy: true},
+		{name: "main team", team: "main", forceDestroy: true, expectError: true},
+	}
+
+	for _, c := range cases {
+		fake := newFakeATC()
+		id := fake.AddTeam(c.team, nil)
+		for _, pipeline := range c.pipelines {
+			fake.AddPipeline(c.team, pipeline, atc.Config{})
+		}
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		state := &terraform.InstanceState{
+			ID: strconv.Itoa(id),
+			Attributes: map[string]string{
+				"id":            strconv.Itoa(id),
+				"name":          c.team,
+				"force_destroy": strconv.FormatBool(c.forceDestroy),
+			},
+		}
+
+		_, err := resourceTeam().Apply(state, &terraform.InstanceDiff{Destroy: true}, cfg)
+		if c.expectError != (err != nil) {
+			t.Fatalf("%s: expected error %v, got %v", c.name, c.expectError, err)
+		}
+
+		_, exists := fake.Team(c.team)
+		if exists != c.expectError {
+			t.Fatalf("%s: expected team to exist %v, got %v", c.name, c.expectError, exists)
+		}
+		if c.expectError && len(fake.Pipelines[c.team]) != len(c.pipelines) {
+			t.Fatalf("%s: expected pipelines to be kept, got %v", c.name, fake.Pipelines[c.team])
+		}
+	}
+}
+
+func TestResourceTeamAdoptExisting(t *testing.T) {
+	cases := []struct {
+		name         string
+		adopt        string
+		users        []interface{}
+		expectError  bool
+		expectedAuth []string
+	}{
+		{name: "fail", adopt: "fail", users: []interface{}{"github:alice"}, expectError: true, expectedAuth: []string{"github:alice"}},
+		{name: "identical", adopt: "identical", users: []interface{}{"github:alice"}, expectedAuth: []string{"github:alice"}},
+		{name: "not identical", adopt: "identical", users: []interface{}{"github:bob"}, expectError: true, expectedAuth: []string{"github:alice"}},
+		{name: "overwrite", adopt: "overwrite", users: []interface{}{"github:bob"}, expectedAuth: []string{"github:bob"}},
+	}
+
+	for _, c := range cases {
+		fake := newFakeATC()
+		id := fake.AddTeam("team-a", map[string]map[string][]string{
+			"owner":  {"users": {"local:admin"}},
+			"member": {"users": {"github:alice"}},
+		})
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		state, err := applyResource(t, resourceTeam(), nil, map[string]interface{}{
+			"name":           "team-a",
+			"auth_users":     c.users,
+			"adopt_existing": c.adopt,
+		}, cfg)
+		if c.expectError != (err != nil) {
+			t.Fatalf("%s: expected error %v, got %v", c.name, c.expectError, err)
+		}
+		if !c.expectError && state.ID != strconv.Itoa(id) {
+			t.Fatalf("%s: expected existing team %d to be adopted, got %s", c.name, id, state.ID)
+		}
+
+		team, _ := fake.Team("team-a")
+		if !reflect.DeepEqual(team.Auth["member"]["users"], c.expectedAuth) {
+			t.Fatalf("%s: expected member users %v, got %v", c.name, c.expectedAuth, team.Auth["member"]["users"])
+		}
+		if !reflect.DeepEqual(team.Auth["owner"]["users"], []string{"local:admin"}) {
+			t.Fatalf("%s: expected owners to be kept, got %v", c.name, team.Auth["owner"])
+		}
+	}
+}
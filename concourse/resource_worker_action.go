@@ -0,0 +1,204 @@
+package concourse
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// workerActionStates lists, per action, the worker states from which the action can be applied and the states in
+// which the action has already been applied.
+var workerActionStates = map[string]struct {
+	from []string
+	done []string
+}{
+	"land":   {from: []string{"running"}, done: []string{"landing", "landed"}},
+	"retire": {from: []string{"running", "landing", "landed"}, done: []string{"retiring"}},
+	"prune":  {from: []string{"stalled", "landing", "landed", "retiring"}},
+}
+
+// checkWorkerAction reports whether the given action needs to be applied to a worker in the given state and
+// returns an error if the worker cannot be transitioned.
+func checkWorkerAction(action string, worker atc.Worker) (bool, error) {
+	states := workerActionStates[action]
+	if containsString(states.from, worker.State) {
+		return true, nil
+	}
+	if containsString(states.done, worker.State) {
+		return false, nil
+	}
+	return false, fmt.Errorf("cannot %s worker \"%s\" in state \"%s\" (allowed states: %s)", action, worker.Name, worker.State, strings.Join(states.from, ", "))
+}
+
+// retireWorker retires a worker. The Concourse client does not support this endpoint, so we have to send the
+// request ourselves.
+func retireWorker(client concourse.Client, name string) error {
+	retireURL := fmt.Sprintf("%s/api/v1/workers/%s/retire", strings.TrimRight(client.URL(), "/"), url.PathEscape(name))
+	req, err := http.NewRequest(http.MethodPut, retireURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s returned status code %d: %s", retireURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func resourceWorkerActionCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Config).Concourse()
+
+	action := d.Get("action").(string)
+	names := expandStringList(d.Get("workers").([]interface{}))
+	filter := workerFilter{
+		State: d.Get("state").(string),
+	}
+
+	if len(names) == 0 && filter.State == "" {
+		return fmt.Errorf("either workers or state must be set")
+	}
+
+	workers, err := client.ListWorkers()
+	if err != nil {
+		return fmt.Errorf("unable to list workers: %v", err)
+	}
+
+	// All workers are validated before any of them is transitioned, so that an invalid worker does not leave
+	// us with a partially applied action.
+	var selected []atc.Worker
+	if len(names) > 0 {
+		for _, name := range names {
+			var worker *atc.Worker
+			for i := range workers {
+				if workers[i].Name == name {
+					worker = &workers[i]
+					break
+				}
+			}
+			if worker == nil {
+				return fmt.Errorf("worker \"%s\" not found", name)
+			}
+			if !filter.matches(*worker) {
+				continue
+			}
+			selected = append(selected, *worker)
+		}
+	} else {
+		for _, worker := range workers {
+			if filter.matches(worker) {
+				selected = append(selected, worker)
+			}
+		}
+	}
+
+	var pending []string
+	for _, worker := range selected {
+		apply, err := checkWorkerAction(action, worker)
+		if err != nil {
+			return err
+		}
+		if apply {
+			pending = append(pending, worker.Name)
+		}
+	}
+	sort.Strings(pending)
+
+	// The ID describes the request rather than the affected workers, which may be none. It is set before any
+	// worker is touched, so that the workers an action has been applied to are kept in the state if a later one
+	// fails. The resource is tainted then and the action is applied again to the remaining workers.
+	id := fmt.Sprintf("%s/workers=%s", action, strings.Join(names, ","))
+	if filter.State != "" {
+		id += "/state=" + filter.State
+	}
+	d.SetId(id)
+
+	affected := make([]interface{}, 0, len(pending))
+	for _, name := range pending {
+		var err error
+		switch action {
+		case "land":
+			err = client.LandWorker(name)
+		case "retire":
+			err = retireWorker(client, name)
+		case "prune":
+			err = client.PruneWorker(name)
+		}
+		if err != nil {
+			d.Set("affected", affected)
+			return fmt.Errorf("unable to %s worker \"%s\": %v", action, name, err)
+		}
+		affected = append(affected, name)
+	}
+
+	if err := d.Set("affected", affected); err != nil {
+		return fmt.Errorf("unable to set affected field: %v", err)
+	}
+
+	return nil
+}
+
+func resourceWorkerActionRead(d *schema.ResourceData, m interface{}) error {
+	// Worker actions are one-off operations, there is nothing to refresh.
+	return nil
+}
+
+func resourceWorkerActionDelete(d *schema.ResourceData, m interface{}) error {
+	// Worker actions cannot be undone, so we only drop them from the state.
+	return nil
+}
+
+func resourceWorkerAction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceWorkerActionCreate,
+		Read:   resourceWorkerActionRead,
+		Delete: resourceWorkerActionDelete,
+		Schema: map[string]*schema.Schema{
+			"action": {
+				Description:  "Action to apply: land, retire or prune",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"land", "retire", "prune"}, false),
+			},
+			"workers": {
+				Description: "Names of the workers to apply the action to",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"state": {
+				Description: "Only apply the action to workers in the given state",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary values that apply the action again when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"affected": {
+				Description: "Names of the workers the action has been applied to",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
@@ -0,0 +1,140 @@
+package concourse
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestCheckWorkerAction(t *testing.T) {
+	cases := []struct {
+		action string
+		state  string
+		apply  bool
+		err    bool
+	}{
+		{"land", "running", true, false},
+		{"land", "landed", false, false},
+		{"land", "stalled", false, true},
+		{"retire", "landed", true, false},
+		{"retire", "retiring", false, false},
+		{"retire", "stalled", false, true},
+		{"prune", "stalled", true, false},
+		{"prune", "running", false, true},
+	}
+
+	for _, c := range cases {
+		apply, err := checkWorkerAction(c.action, atc.Worker{Name: "worker", State: c.state})
+		if (err != nil) != c.err {
+			t.Fatalf("%s from %s: expected error %v, got %v", c.action, c.state, c.err, err)
+		}
+		if apply != c.apply {
+			t.Fatalf("%s from %s: expected apply %v, got %v", c.action, c.state, c.apply, apply)
+		}
+	}
+}
+
+func TestResourceWorkerAction(t *testing.T) {
+	workers := []atc.Worker{
+		{Name: "w1", State: "running"},
+		{Name: "w2", State: "running"},
+		{Name: "w3", State: "landed"},
+		{Name: "w4", State: "stalled"},
+		{Name: "w5", State: "stalled"},
+	}
+
+	cases := []struct {
+		name             string
+		config           map[string]interface{}
+		errors           map[string]int
+		expectError      bool
+		expectedID       string
+		expectedAffected []string
+		expectedStates   map[string]string
+	}{
+		{
+			name:             "land by name",
+			config:           map[string]interface{}{"action": "land", "workers": []interface{}{"w2", "w1", "w3"}},
+			expectedID:       "land/workers=w2,w1,w3",
+			expectedAffected: []string{"w1", "w2"},
+			expectedStates:   map[string]string{"w1": "landing", "w2": "landing", "w3": "landed"},
+		},
+		{
+			name:             "retire by state",
+			config:           map[string]interface{}{"action": "retire", "state": "landed"},
+			expectedID:       "retire/workers=/state=landed",
+			expectedAffected: []string{"w3"},
+			expectedStates:   map[string]string{"w1": "running", "w3": "retiring"},
+		},
+		{
+			name:             "prune by state",
+			config:           map[string]interface{}{"action": "prune", "state": "stalled"},
+			expectedID:       "prune/workers=/state=stalled",
+			expectedAffected: []string{"w4", "w5"},
+			expectedStates:   map[string]string{"w1": "running", "w4": "", "w5": ""},
+		},
+		{
+			name:             "no matching worker",
+			config:           map[string]interface{}{"action": "prune", "state": "retiring"},
+			expectedID:       "prune/workers=/state=retiring",
+			expectedAffected: []string{},
+			expectedStates:   map[string]string{"w4": "stalled"},
+		},
+		{
+			name:           "invalid transition",
+			config:         map[string]interface{}{"action": "prune", "workers": []interface{}{"w4", "w1"}},
+			expectError:    true,
+			expectedStates: map[string]string{"w1": "running", "w4": "stalled"},
+		},
+		{
+			name:             "partial failure",
+			config:           map[string]interface{}{"action": "prune", "state": "stalled"},
+			errors:           map[string]int{"PUT /api/v1/workers/w5/prune": http.StatusInternalServerError},
+			expectError:      true,
+			expectedID:       "prune/workers=/state=stalled",
+			expectedAffected: []string{"w4"},
+			expectedStates:   map[string]string{"w4": "", "w5": "stalled"},
+		},
+	}
+
+	for _, c := range cases {
+		fake := newFakeATC()
+		fake.Workers = append([]atc.Worker{}, workers...)
+		for k, v := range c.errors {
+			fake.Errors[k] = v
+		}
+		cfg, stop := fake.Start(t)
+		defer stop()
+
+		state, err := applyResource(t, resourceWorkerAction(), nil, c.config, cfg)
+		if c.expectError != (err != nil) {
+			t.Fatalf("%s: expected error %v, got %v", c.name, c.expectError, err)
+		}
+
+		if c.expectedID != "" {
+			if state == nil || state.ID != c.expectedID {
+				t.Fatalf("%s: expected ID %s, got %v", c.name, c.expectedID, state)
+			}
+			if count := state.Attributes["affected.#"]; count != strconv.Itoa(len(c.expectedAffected)) {
+				t.Fatalf("%s: expected %d affected workers, got %s", c.name, len(c.expectedAffected), count)
+			}
+			for i, name := range c.expectedAffected {
+				if actual := state.Attributes["affected."+strconv.Itoa(i)]; actual != name {
+					t.Fatalf("%s: expected affected worker %s at index %d, got %s", c.name, name, i, actual)
+				}
+			}
+		}
+
+		states := map[string]string{}
+		for _, worker := range fake.Workers {
+			states[worker.Name] = worker.State
+		}
+		for name, expected := range c.expectedStates {
+			if states[name] != expected {
+				t.Fatalf("%s: expected worker %s to be in state %q, got %q", c.name, name, expected, states[name])
+			}
+		}
+	}
+}